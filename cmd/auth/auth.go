@@ -6,6 +6,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/rediacc/cli/internal/api"
+	"github.com/rediacc/cli/internal/cliutil"
 	"github.com/rediacc/cli/internal/config"
 )
 
@@ -14,7 +15,7 @@ var AuthCmd = &cobra.Command{
 	Use:   "auth",
 	Short: "Authentication and user management commands",
 	Long: `Authentication and user management commands for Rediacc CLI.
-	
+
 This command group includes login, logout, user management, and 2FA operations.`,
 }
 
@@ -23,7 +24,7 @@ var loginCmd = &cobra.Command{
 	Use:   "login",
 	Short: "Login to Rediacc",
 	Long:  "Authenticate with Rediacc using email and password",
-	RunE:  runLogin,
+	Run:   runLogin,
 }
 
 // logoutCmd represents the logout command
@@ -31,7 +32,7 @@ var logoutCmd = &cobra.Command{
 	Use:   "logout",
 	Short: "Logout from Rediacc",
 	Long:  "Logout from the current Rediacc session",
-	RunE:  runLogout,
+	Run:   runLogout,
 }
 
 // statusCmd represents the status command
@@ -39,19 +40,39 @@ var statusCmd = &cobra.Command{
 	Use:   "status",
 	Short: "Show authentication status",
 	Long:  "Display current authentication status and user information",
-	RunE:  runStatus,
+	Run:   runStatus,
+}
+
+// whoamiCmd prints the identity of the currently authenticated user
+var whoamiCmd = &cobra.Command{
+	Use:   "whoami",
+	Short: "Show the current user",
+	Long:  "Print the email address of the currently authenticated user",
+	Run:   runWhoami,
 }
 
-func runLogin(cmd *cobra.Command, args []string) error {
+// refreshCmd forces a manual rotation of the current session's
+// RequestCredential, instead of waiting for the next proactive refresh.
+var refreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Force a session token refresh",
+	Long:  "Rotate the current session's RequestCredential immediately via RefreshAuthenticationRequest, instead of waiting for the next proactive refresh.",
+	Run:   runRefresh,
+}
+
+func runLogin(cmd *cobra.Command, args []string) {
 	email, _ := cmd.Flags().GetString("email")
 	password, _ := cmd.Flags().GetString("password")
+	twoFACode, _ := cmd.Flags().GetString("2fa-code")
 
 	// Validate input
 	if email == "" {
-		return errors.New("email is required")
+		cliutil.Fatal(cmd, errors.New("email is required"))
+		return
 	}
 	if password == "" {
-		return errors.New("password is required")
+		cliutil.Fatal(cmd, errors.New("password is required"))
+		return
 	}
 
 	// Create API client
@@ -60,61 +81,98 @@ func runLogin(cmd *cobra.Command, args []string) error {
 
 	// Attempt login
 	fmt.Printf("Logging in as %s...\n", email)
-	response, err := client.Login(email, password)
+	_, err := client.Login(email, password, twoFACode)
 	if err != nil {
-		return fmt.Errorf("login failed: %w", err)
+		cliutil.Fatal(cmd, fmt.Errorf("login failed: %w", err))
+		return
 	}
 
-	if response.Success {
-		fmt.Printf("✓ Successfully logged in as %s\n", email)
-		return nil
+	fmt.Printf("✓ Successfully logged in as %s\n", email)
+}
+
+func runWhoami(cmd *cobra.Command, args []string) {
+	cfg := config.Get()
+	if cfg.Auth.Email == "" {
+		cliutil.Fatal(cmd, fmt.Errorf("not logged in"))
+		return
 	}
 
-	return fmt.Errorf("login failed: %s", response.Error)
+	fmt.Println(cfg.Auth.Email)
 }
 
-func runLogout(cmd *cobra.Command, args []string) error {
+func runLogout(cmd *cobra.Command, args []string) {
 	cfg := config.Get()
 	if cfg.Auth.Email == "" {
-		return fmt.Errorf("not logged in")
+		cliutil.Fatal(cmd, fmt.Errorf("not logged in"))
+		return
+	}
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
 	}
 
-	client := api.NewClient(cfg.Server.URL)
-	
 	fmt.Printf("Logging out %s...\n", cfg.Auth.Email)
 	if err := client.Logout(); err != nil {
-		return fmt.Errorf("logout failed: %w", err)
+		cliutil.Fatal(cmd, fmt.Errorf("logout failed: %w", err))
+		return
 	}
 
 	fmt.Println("✓ Successfully logged out")
-	return nil
 }
 
-func runStatus(cmd *cobra.Command, args []string) error {
+func runStatus(cmd *cobra.Command, args []string) {
 	cfg := config.Get()
-	
+
 	if cfg.Auth.Email == "" {
 		fmt.Println("Status: Not logged in")
-		return nil
+		return
 	}
 
 	fmt.Printf("Status: Logged in as %s\n", cfg.Auth.Email)
 	fmt.Printf("Server: %s\n", cfg.Server.URL)
-	
-	if cfg.Auth.RequestCredential != "" {
+	fmt.Printf("Credential store: %s\n", config.CredentialsBackend())
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	if client.IsAuthenticated() {
 		fmt.Println("Session: Active")
-		fmt.Printf("Request Credential: %s\n", cfg.Auth.RequestCredential)
 	} else {
 		fmt.Println("Session: No token")
 	}
+}
+
+func runRefresh(cmd *cobra.Command, args []string) {
+	cfg := config.Get()
+	if cfg.Auth.Email == "" {
+		cliutil.Fatal(cmd, fmt.Errorf("not logged in"))
+		return
+	}
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	if err := client.RefreshToken(); err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("token refresh failed: %w", err))
+		return
+	}
 
-	return nil
+	fmt.Println("✓ Session token refreshed")
 }
 
 func init() {
 	// Add login command flags
 	loginCmd.Flags().StringP("email", "e", "", "Email address")
 	loginCmd.Flags().StringP("password", "p", "", "Password")
+	loginCmd.Flags().String("2fa-code", "", "Two-factor authentication code, if enabled on the account")
 	loginCmd.MarkFlagRequired("email")
 	loginCmd.MarkFlagRequired("password")
 
@@ -122,4 +180,6 @@ func init() {
 	AuthCmd.AddCommand(loginCmd)
 	AuthCmd.AddCommand(logoutCmd)
 	AuthCmd.AddCommand(statusCmd)
+	AuthCmd.AddCommand(whoamiCmd)
+	AuthCmd.AddCommand(refreshCmd)
 }
@@ -0,0 +1,168 @@
+package auth
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rediacc/cli/internal/api"
+	"github.com/rediacc/cli/internal/cliutil"
+	"github.com/rediacc/cli/internal/config"
+)
+
+// certEnrollCmd generates a key pair and CSR locally, submits the CSR to
+// the middleware for signing, and stores the result for mTLS auth.
+var certEnrollCmd = &cobra.Command{
+	Use:   "cert-enroll",
+	Short: "Enroll for mTLS client-certificate authentication",
+	Long: `Generate a local key pair and certificate signing request, submit it to
+the middleware's EnrollClientCertificate procedure (authenticated with
+email/password, same as login), and store the signed certificate and key
+under ~/.rediacc-cli/ with 0600 permissions.
+
+This is meant for bouncer/agent-style machine auth (CI runners,
+server-to-server callers) where a long-lived password is undesirable: once
+enrolled, "auth cert-login" and NewAuthenticatedClient use the certificate
+instead of a session token.`,
+	Run: runCertEnroll,
+}
+
+// certLoginCmd activates a previously enrolled certificate as the current
+// auth identity, without needing a password.
+var certLoginCmd = &cobra.Command{
+	Use:   "cert-login",
+	Short: "Authenticate using an enrolled client certificate",
+	Long:  "Verify the enrolled client certificate against the server and make it the active auth identity, without a password.",
+	Run:   runCertLogin,
+}
+
+func runCertEnroll(cmd *cobra.Command, args []string) {
+	email, _ := cmd.Flags().GetString("email")
+	password, _ := cmd.Flags().GetString("password")
+	commonName, _ := cmd.Flags().GetString("common-name")
+	sans, _ := cmd.Flags().GetStringSlice("san")
+
+	if email == "" || password == "" {
+		cliutil.Fatal(cmd, fmt.Errorf("--email and --password are required"))
+		return
+	}
+	if commonName == "" {
+		commonName = email
+	}
+
+	csrPEM, keyPEM, err := api.GenerateCSR(commonName, sans)
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	cfg := config.Get()
+	client := api.NewClient(cfg.Server.URL)
+
+	fmt.Printf("Submitting certificate signing request for %s...\n", commonName)
+	response, err := client.EnrollCertificate(email, password, csrPEM)
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("certificate enrollment failed: %w", err))
+		return
+	}
+	if response.CertificatePEM == "" {
+		cliutil.Fatal(cmd, fmt.Errorf("certificate enrollment succeeded but the server returned no certificate"))
+		return
+	}
+
+	certPath, keyPath, caPath, err := writeCertFiles(keyPEM, []byte(response.CertificatePEM), []byte(response.CABundlePEM))
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	if err := config.UpdateClientCert(certPath, keyPath, caPath); err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to save certificate paths: %w", err))
+		return
+	}
+
+	fmt.Printf("✓ Enrolled, certificate stored at %s\n", certPath)
+}
+
+func runCertLogin(cmd *cobra.Command, args []string) {
+	cfg := config.Get()
+	if cfg.Auth.ClientCertFile == "" || cfg.Auth.ClientKeyFile == "" {
+		cliutil.Fatal(cmd, fmt.Errorf("no enrolled certificate found, run `auth cert-enroll` first"))
+		return
+	}
+
+	client, err := api.NewClientWithCert(cfg.Server.URL, api.ClientConfig{
+		CertFile: cfg.Auth.ClientCertFile,
+		KeyFile:  cfg.Auth.ClientKeyFile,
+		CAFile:   cfg.Auth.CAFile,
+	})
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load client certificate: %w", err))
+		return
+	}
+
+	_, err = client.ExecuteStoredProcedure("GetUserCompanyDetails", map[string]interface{}{})
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("certificate authentication failed: %w", err))
+		return
+	}
+
+	email, _ := cmd.Flags().GetString("email")
+	if email != "" {
+		if err := config.UpdateAuth(email, "", ""); err != nil {
+			cliutil.Fatal(cmd, fmt.Errorf("failed to record active identity: %w", err))
+			return
+		}
+	}
+
+	fmt.Println("✓ Authenticated via client certificate")
+}
+
+// writeCertFiles persists the enrolled key, certificate, and (if present)
+// CA bundle under ~/.rediacc-cli/ with 0600 permissions.
+func writeCertFiles(keyPEM, certPEM, caPEM []byte) (certPath, keyPath, caPath string, err error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", "", "", fmt.Errorf("could not get home directory: %w", err)
+	}
+
+	dir := filepath.Join(home, ".rediacc-cli")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return "", "", "", fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	certPath = filepath.Join(dir, "client.crt")
+	keyPath = filepath.Join(dir, "client.key")
+
+	if err := os.WriteFile(keyPath, keyPEM, 0600); err != nil {
+		return "", "", "", fmt.Errorf("failed to write client key: %w", err)
+	}
+	if err := os.WriteFile(certPath, certPEM, 0600); err != nil {
+		return "", "", "", fmt.Errorf("failed to write client certificate: %w", err)
+	}
+
+	if len(caPEM) > 0 {
+		caPath = filepath.Join(dir, "ca.crt")
+		if err := os.WriteFile(caPath, caPEM, 0600); err != nil {
+			return "", "", "", fmt.Errorf("failed to write CA bundle: %w", err)
+		}
+	}
+
+	return certPath, keyPath, caPath, nil
+}
+
+func init() {
+	certEnrollCmd.Flags().StringP("email", "e", "", "Email address to authenticate the enrollment request")
+	certEnrollCmd.Flags().StringP("password", "p", "", "Password to authenticate the enrollment request")
+	certEnrollCmd.Flags().String("common-name", "", "Certificate common name (defaults to --email)")
+	certEnrollCmd.Flags().StringSlice("san", nil, "Subject alternative name(s) to request, e.g. spiffe://rediacc/ci-runner/prod")
+	certEnrollCmd.MarkFlagRequired("email")
+	certEnrollCmd.MarkFlagRequired("password")
+
+	certLoginCmd.Flags().StringP("email", "e", "", "Email to record as the active identity after a successful certificate check")
+
+	AuthCmd.AddCommand(certEnrollCmd)
+	AuthCmd.AddCommand(certLoginCmd)
+}
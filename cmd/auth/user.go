@@ -1,15 +1,34 @@
 package auth
 
 import (
+	"bufio"
 	"crypto/sha256"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/rediacc/cli/internal/api"
+	"github.com/rediacc/cli/internal/cliutil"
+	"github.com/rediacc/cli/internal/completion"
 	"github.com/rediacc/cli/internal/config"
 	"github.com/rediacc/cli/internal/format"
+	"github.com/rediacc/cli/internal/prompt"
+	"github.com/rediacc/cli/internal/validate"
 )
 
+// userCreateFlags declares runUserCreate's --email constraints once via
+// struct tags instead of hand-rolled checks, per internal/validate.
+type userCreateFlags struct {
+	Email string `yaml:"email" validate:"required,regexp=^[^@\\s]+@[^@\\s]+\\.[^@\\s]+$"`
+}
+
+// completeUserIdentifiers offers cached user emails for any number of
+// identifier arguments.
+func completeUserIdentifiers(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return completion.UserEmails(cmd.Context(), completion.DefaultTTL), cobra.ShellCompDirectiveNoFileComp
+}
+
 // userCmd represents the user command
 var userCmd = &cobra.Command{
 	Use:   "user",
@@ -22,7 +41,7 @@ var userCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new user",
 	Long:  "Create a new user in the system",
-	RunE:  runUserCreate,
+	Run:   runUserCreate,
 }
 
 // userListCmd lists users
@@ -30,219 +49,367 @@ var userListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List users",
 	Long:  "List all users in the system",
-	RunE:  runUserList,
+	Run:   runUserList,
 }
 
 // userInfoCmd shows user information
 var userInfoCmd = &cobra.Command{
-	Use:   "info <email>",
-	Short: "Show user information",
-	Long:  "Display detailed information about a specific user",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runUserInfo,
+	Use:               "info <identifier>...",
+	Short:             "Show user information",
+	Long:              "Display detailed information about one or more users, identified by email, username, or user ID",
+	Args:              cobra.ArbitraryArgs,
+	Run:               runUserInfo,
+	ValidArgsFunction: completeUserIdentifiers,
 }
 
 // userActivateCmd activates a user
 var userActivateCmd = &cobra.Command{
-	Use:   "activate <email>",
-	Short: "Activate a user",
-	Long:  "Activate a user account",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runUserActivate,
+	Use:               "activate <identifier>...",
+	Short:             "Activate one or more users",
+	Long:              "Activate user accounts, identified by email, username, or user ID",
+	Args:              cobra.ArbitraryArgs,
+	Run:               runUserActivate,
+	ValidArgsFunction: completeUserIdentifiers,
 }
 
 // userDeactivateCmd deactivates a user
 var userDeactivateCmd = &cobra.Command{
-	Use:   "deactivate <email>",
-	Short: "Deactivate a user",
-	Long:  "Deactivate a user account",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runUserDeactivate,
+	Use:               "deactivate <identifier>...",
+	Short:             "Deactivate one or more users",
+	Long:              "Deactivate user accounts, identified by email, username, or user ID",
+	Args:              cobra.ArbitraryArgs,
+	Run:               runUserDeactivate,
+	ValidArgsFunction: completeUserIdentifiers,
 }
 
 // userUpdatePasswordCmd updates user password
 var userUpdatePasswordCmd = &cobra.Command{
-	Use:   "update-password <email>",
-	Short: "Update user password",
-	Long:  "Update the password for a user",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runUserUpdatePassword,
+	Use:               "update-password <identifier>...",
+	Short:             "Update password for one or more users",
+	Long:              "Update the password for users, identified by email, username, or user ID",
+	Args:              cobra.ArbitraryArgs,
+	Run:               runUserUpdatePassword,
+	ValidArgsFunction: completeUserIdentifiers,
 }
 
-func runUserCreate(cmd *cobra.Command, args []string) error {
-	email, _ := cmd.Flags().GetString("email")
-	password, _ := cmd.Flags().GetString("password")
+// batchOpFunc performs a single-user operation and returns an error on failure.
+type batchOpFunc func(client *api.Client, user map[string]interface{}) error
+
+// resolveIdentifiers expands positional args and --from-file into a flat, deduplicated identifier list.
+func resolveIdentifiers(cmd *cobra.Command, args []string) ([]string, error) {
+	identifiers := append([]string{}, args...)
 
-	if email == "" || password == "" {
-		return fmt.Errorf("email and password are required")
+	fromFile, _ := cmd.Flags().GetString("from-file")
+	if fromFile != "" {
+		f, err := os.Open(fromFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open --from-file %s: %w", fromFile, err)
+		}
+		defer f.Close()
+
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			identifiers = append(identifiers, line)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read --from-file %s: %w", fromFile, err)
+		}
 	}
 
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+	if len(identifiers) == 0 {
+		return nil, fmt.Errorf("at least one identifier is required (positional argument or --from-file)")
+	}
 
-	// CreateNewUser expects NewUserEmail and NewUserHash parameters
-	// Middleware adds "prm" prefix automatically: NewUserEmail -> @prmNewUserEmail
-	// Hash the password and format as hex string for binary parameter
-	hash := sha256.Sum256([]byte(password))
-	hexHash := fmt.Sprintf("0x%x", hash[:])
-	
-	params := map[string]interface{}{
-		"NewUserEmail": email,
-		"NewUserHash":  hexHash,
+	seen := make(map[string]bool, len(identifiers))
+	deduped := make([]string, 0, len(identifiers))
+	for _, id := range identifiers {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		deduped = append(deduped, id)
 	}
 
-	// CreateNewUser is public but requires current user password for validation
-	adminEmail := cfg.Auth.Email
-	
-	fmt.Printf("Enter password for %s to create user: ", adminEmail)
-	var adminPassword string
-	fmt.Scanln(&adminPassword)
-	
-	response, err := client.ExecuteAuthProcedure("CreateNewUser", params, adminEmail, adminPassword)
-	if err != nil {
-		return fmt.Errorf("failed to create user: %w", err)
+	return deduped, nil
+}
+
+// resolveUser finds the company user row matching an email, username, or user ID.
+func resolveUser(identifier string, users []map[string]interface{}) (map[string]interface{}, error) {
+	for _, user := range users {
+		if email, ok := user["email"].(string); ok && email == identifier {
+			return user, nil
+		}
+		if username, ok := user["username"].(string); ok && username == identifier {
+			return user, nil
+		}
+		if id, ok := user["id"]; ok && fmt.Sprintf("%v", id) == identifier {
+			return user, nil
+		}
 	}
+	return nil, fmt.Errorf("no user found matching '%s'", identifier)
+}
 
-	if response.Success {
-		format.PrintSuccess("✓ User %s created successfully", email)
-		return nil
+// runBatchUserOp resolves each identifier against the company user list and applies op to every match,
+// honoring --dry-run and --continue-on-error, then prints an aggregated summary.
+func runBatchUserOp(cmd *cobra.Command, args []string, actionVerb string, op batchOpFunc) error {
+	identifiers, err := resolveIdentifiers(cmd, args)
+	if err != nil {
+		return err
 	}
 
-	return fmt.Errorf("failed to create user: %s", response.Error)
-}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	continueOnError, _ := cmd.Flags().GetBool("continue-on-error")
 
-func runUserList(cmd *cobra.Command, args []string) error {
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("failed to load session: %w", err)
+	}
 
-	response, err := client.ExecuteStoredProcedure("GetAllCompanyUsers", map[string]interface{}{})
+	usersResp, err := client.ExecuteStoredProcedure("GetAllCompanyUsers", map[string]interface{}{})
 	if err != nil {
-		return fmt.Errorf("failed to list users: %w", err)
+		return fmt.Errorf("failed to load company users: %w", err)
 	}
 
-	if response.Success {
-		if len(response.Data) == 0 {
-			fmt.Println("No users found")
-			return nil
+	var succeeded, failed []string
+	for _, identifier := range identifiers {
+		user, err := resolveUser(identifier, usersResp.Data)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", identifier, err))
+			if !continueOnError {
+				break
+			}
+			continue
 		}
-		return format.Print(response.Data)
-	}
 
-	return fmt.Errorf("failed to list users: %s", response.Error)
-}
+		email, _ := user["email"].(string)
 
-func runUserInfo(cmd *cobra.Command, args []string) error {
-	email := args[0]
+		if dryRun {
+			format.PrintInfo("(dry-run) would %s user %s", actionVerb, email)
+			succeeded = append(succeeded, email)
+			continue
+		}
 
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+		if err := op(client, user); err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", email, err))
+			if !continueOnError {
+				break
+			}
+			continue
+		}
 
-	params := map[string]interface{}{
-		"email": email,
+		format.PrintSuccess("✓ User %s %s successfully", email, actionVerb+"d")
+		succeeded = append(succeeded, email)
 	}
 
-	response, err := client.ExecuteStoredProcedure("GetAllCompanyUsers", params)
-	if err != nil {
-		return fmt.Errorf("failed to get user info: %w", err)
+	if len(identifiers) > 1 || continueOnError {
+		fmt.Printf("\nSummary: %d succeeded, %d failed\n", len(succeeded), len(failed))
+		for _, failure := range failed {
+			fmt.Printf("  - %s\n", failure)
+		}
 	}
 
-	if response.Success {
-		if len(response.Data) == 0 {
-			return fmt.Errorf("user %s not found", email)
-		}
-		return format.Print(response.Data[0])
+	if len(failed) > 0 {
+		return fmt.Errorf("%d of %d operations failed", len(failed), len(identifiers))
 	}
+	return nil
+}
 
-	return fmt.Errorf("failed to get user info: %s", response.Error)
+// resolveNewUserPassword reads the password for a new user from the first
+// configured source: --password-file, --password-stdin, --generate-password,
+// or (default) an interactive, no-echo, confirmed prompt. The -p/--password
+// flag is intentionally not part of this chain: passwords passed as plain
+// arguments leak into shell history and ps output.
+func resolveNewUserPassword(cmd *cobra.Command) (string, error) {
+	passwordFile, _ := cmd.Flags().GetString("password-file")
+	passwordStdin, _ := cmd.Flags().GetBool("password-stdin")
+	generate, _ := cmd.Flags().GetBool("generate-password")
+	return prompt.Resolve("", passwordFile, passwordStdin, generate, "Password: ")
 }
 
-func runUserActivate(cmd *cobra.Command, args []string) error {
-	email := args[0]
+func runUserCreate(cmd *cobra.Command, args []string) {
+	email, _ := cmd.Flags().GetString("email")
+	if err := validate.Validate(&userCreateFlags{Email: email}); err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	password, err := resolveNewUserPassword(cmd)
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
 
 	cfg := config.Get()
 	client := api.NewClient(cfg.Server.URL)
 
-	// EnableUserAccount expects userEmail parameter
+	// CreateNewUser expects NewUserEmail and NewUserHash parameters
+	// Middleware adds "prm" prefix automatically: NewUserEmail -> @prmNewUserEmail
+	// Hash the password and format as hex string for binary parameter
+	hash := sha256.Sum256([]byte(password))
+	hexHash := fmt.Sprintf("0x%x", hash[:])
+
 	params := map[string]interface{}{
-		"userEmail": email,
+		"NewUserEmail": email,
+		"NewUserHash":  hexHash,
 	}
 
-	response, err := client.ExecuteStoredProcedure("EnableUserAccount", params)
+	// CreateNewUser is public but requires current user password for validation
+	adminEmail := cfg.Auth.Email
+
+	adminPassword, err := prompt.Password(fmt.Sprintf("Enter password for %s to create user: ", adminEmail))
 	if err != nil {
-		return fmt.Errorf("failed to activate user: %w", err)
+		cliutil.Fatal(cmd, err)
+		return
 	}
 
-	if response.Success {
-		format.PrintSuccess("✓ User %s activated successfully", email)
-		return nil
+	_, err = client.ExecuteAuthProcedure("CreateNewUser", params, adminEmail, adminPassword)
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to create user: %w", err))
+		return
 	}
 
-	return fmt.Errorf("failed to activate user: %s", response.Error)
+	format.PrintSuccess("✓ User %s created successfully", email)
 }
 
-func runUserDeactivate(cmd *cobra.Command, args []string) error {
-	email := args[0]
-
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
-
-	// DisableUserAccount expects userEmail parameter
-	params := map[string]interface{}{
-		"userEmail": email,
+func runUserList(cmd *cobra.Command, args []string) {
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
 	}
 
-	response, err := client.ExecuteStoredProcedure("DisableUserAccount", params)
+	response, err := client.ExecuteStoredProcedure("GetAllCompanyUsers", map[string]interface{}{})
 	if err != nil {
-		return fmt.Errorf("failed to deactivate user: %w", err)
+		cliutil.Fatal(cmd, fmt.Errorf("failed to list users: %w", err))
+		return
 	}
 
-	if response.Success {
-		format.PrintSuccess("✓ User %s deactivated successfully", email)
-		return nil
+	if len(response.Data) == 0 {
+		fmt.Println("No users found")
+		return
 	}
-
-	return fmt.Errorf("failed to deactivate user: %s", response.Error)
+	cliutil.Fatal(cmd, format.Print(response.Data))
 }
 
-func runUserUpdatePassword(cmd *cobra.Command, args []string) error {
-	email := args[0]
-	newPassword, _ := cmd.Flags().GetString("password")
+func runUserInfo(cmd *cobra.Command, args []string) {
+	identifiers, err := resolveIdentifiers(cmd, args)
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
 
-	if newPassword == "" {
-		return fmt.Errorf("new password is required")
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
 	}
 
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+	usersResp, err := client.ExecuteStoredProcedure("GetAllCompanyUsers", map[string]interface{}{})
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to get user info: %w", err))
+		return
+	}
 
-	// UpdateUserPassword expects userNewPass parameter (from tutorial)
-	params := map[string]interface{}{
-		"userNewPass": newPassword,
+	continueOnError, _ := cmd.Flags().GetBool("continue-on-error")
+
+	var matches []map[string]interface{}
+	var failed []string
+	for _, identifier := range identifiers {
+		user, err := resolveUser(identifier, usersResp.Data)
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", identifier, err))
+			if !continueOnError {
+				cliutil.Fatal(cmd, fmt.Errorf("%s", failed[len(failed)-1]))
+				return
+			}
+			continue
+		}
+		matches = append(matches, user)
 	}
 
-	response, err := client.ExecuteStoredProcedure("UpdateUserPassword", params)
-	if err != nil {
-		return fmt.Errorf("failed to update password: %w", err)
+	if len(matches) == 1 && len(failed) == 0 {
+		cliutil.Fatal(cmd, format.Print(matches[0]))
+		return
+	}
+
+	if len(matches) > 0 {
+		if err := format.Print(matches); err != nil {
+			cliutil.Fatal(cmd, err)
+			return
+		}
+	}
+	for _, failure := range failed {
+		format.PrintError(failure)
 	}
+	if len(failed) > 0 {
+		cliutil.Fatal(cmd, fmt.Errorf("%d of %d identifiers could not be resolved", len(failed), len(identifiers)))
+	}
+}
+
+func runUserActivate(cmd *cobra.Command, args []string) {
+	cliutil.Fatal(cmd, runBatchUserOp(cmd, args, "activate", func(client *api.Client, user map[string]interface{}) error {
+		email, _ := user["email"].(string)
+		// EnableUserAccount expects userEmail parameter
+		_, err := client.ExecuteStoredProcedure("EnableUserAccount", map[string]interface{}{
+			"userEmail": email,
+		})
+		return err
+	}))
+}
+
+func runUserDeactivate(cmd *cobra.Command, args []string) {
+	cliutil.Fatal(cmd, runBatchUserOp(cmd, args, "deactivate", func(client *api.Client, user map[string]interface{}) error {
+		email, _ := user["email"].(string)
+		// DisableUserAccount expects userEmail parameter
+		_, err := client.ExecuteStoredProcedure("DisableUserAccount", map[string]interface{}{
+			"userEmail": email,
+		})
+		return err
+	}))
+}
 
-	if response.Success {
-		format.PrintSuccess("✓ Password updated successfully for user %s", email)
-		return format.Print(response.Data)
+func runUserUpdatePassword(cmd *cobra.Command, args []string) {
+	newPassword, err := resolveNewUserPassword(cmd)
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
 	}
 
-	return fmt.Errorf("failed to update password: %s", response.Error)
+	cliutil.Fatal(cmd, runBatchUserOp(cmd, args, "update-password", func(client *api.Client, user map[string]interface{}) error {
+		// UpdateUserPassword expects userNewPass parameter (from tutorial)
+		_, err := client.ExecuteStoredProcedure("UpdateUserPassword", map[string]interface{}{
+			"userNewPass": newPassword,
+		})
+		return err
+	}))
 }
 
 func init() {
 	// User create command flags
 	userCreateCmd.Flags().StringP("email", "e", "", "User email address")
-	userCreateCmd.Flags().StringP("password", "p", "", "User password")
 	userCreateCmd.MarkFlagRequired("email")
-	userCreateCmd.MarkFlagRequired("password")
 
-	// User update password command flags
-	userUpdatePasswordCmd.Flags().StringP("password", "p", "", "New password")
-	userUpdatePasswordCmd.MarkFlagRequired("password")
+	// Password source flags shared by create and update-password. The password
+	// is never accepted as a plain -p/--password argument since that leaks into
+	// shell history and process listings; see internal/prompt.Resolve.
+	for _, c := range []*cobra.Command{userCreateCmd, userUpdatePasswordCmd} {
+		c.Flags().String("password-file", "", "Read the password from the first line of this file")
+		c.Flags().Bool("password-stdin", false, "Read the password from stdin")
+		c.Flags().Bool("generate-password", false, "Generate a strong random password and print it once")
+	}
+
+	// Bulk-operation flags shared by the multi-identifier commands
+	for _, c := range []*cobra.Command{userInfoCmd, userActivateCmd, userDeactivateCmd, userUpdatePasswordCmd} {
+		c.Flags().String("from-file", "", "Read newline-separated identifiers (email, username, or user ID) from a file")
+		c.Flags().Bool("continue-on-error", false, "Continue processing remaining identifiers after a failure and report a summary")
+	}
+	for _, c := range []*cobra.Command{userActivateCmd, userDeactivateCmd, userUpdatePasswordCmd} {
+		c.Flags().Bool("dry-run", false, "Print what would happen without making any changes")
+	}
 
 	// Add subcommands to user command
 	userCmd.AddCommand(userCreateCmd)
@@ -254,4 +421,4 @@ func init() {
 
 	// Add user command to auth command
 	AuthCmd.AddCommand(userCmd)
-}
\ No newline at end of file
+}
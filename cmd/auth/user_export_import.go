@@ -0,0 +1,218 @@
+package auth
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/rediacc/cli/internal/api"
+	"github.com/rediacc/cli/internal/bulk"
+	"github.com/rediacc/cli/internal/cliutil"
+	"github.com/rediacc/cli/internal/config"
+	"github.com/rediacc/cli/internal/format"
+	"github.com/rediacc/cli/internal/prompt"
+)
+
+// userExportCmd dumps company users as JSONL
+var userExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export users as JSONL",
+	Long:  "Export all company users as newline-delimited JSON, for backup or migration. Password hashes are never exported; use --generate-passwords on import instead",
+	Run:   runUserExport,
+}
+
+// userImportCmd loads users from JSONL
+var userImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import users from JSONL",
+	Long:  "Import users from a newline-delimited JSON file produced by `auth user export`",
+	Args:  cobra.ExactArgs(1),
+	Run:   runUserImport,
+}
+
+func runUserExport(cmd *cobra.Command, args []string) {
+	outputFile, _ := cmd.Flags().GetString("file")
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	response, err := client.ExecuteStoredProcedure("GetAllCompanyUsers", map[string]interface{}{})
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to list users: %w", err))
+		return
+	}
+
+	records := make([]map[string]interface{}, 0, len(response.Data))
+	for _, user := range response.Data {
+		record := map[string]interface{}{
+			"type":  "user",
+			"email": user["email"],
+		}
+		if status, ok := user["status"]; ok {
+			record["status"] = status
+		}
+		records = append(records, record)
+	}
+
+	out := os.Stdout
+	if outputFile != "" && outputFile != "-" {
+		f, err := os.Create(outputFile)
+		if err != nil {
+			cliutil.Fatal(cmd, fmt.Errorf("failed to create %s: %w", outputFile, err))
+			return
+		}
+		defer f.Close()
+		out = f
+	}
+
+	cliutil.Fatal(cmd, bulk.WriteJSONL(bufio.NewWriter(out), records))
+}
+
+func runUserImport(cmd *cobra.Command, args []string) {
+	path := args[0]
+
+	onConflictFlag, _ := cmd.Flags().GetString("on-conflict")
+	onConflict, err := bulk.ParseOnConflict(onConflictFlag)
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	generatePasswords, _ := cmd.Flags().GetBool("generate-passwords")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	reportPath, _ := cmd.Flags().GetString("report")
+	parallelism, _ := cmd.Flags().GetInt("parallelism")
+
+	records, err := bulk.ReadJSONL(path)
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	cfg := config.Get()
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	existing, err := loadExistingUserEmails(client)
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	var adminEmail, adminPassword string
+	if !dryRun {
+		adminEmail = cfg.Auth.Email
+		adminPassword, err = prompt.Password(fmt.Sprintf("Enter password for %s to create users: ", adminEmail))
+		if err != nil {
+			cliutil.Fatal(cmd, err)
+			return
+		}
+	}
+
+	// Interactive per-user passwords require reading stdin one at a time, so
+	// only --generate-passwords (no further prompting) runs through the
+	// worker pool; the plain prompt path stays sequential.
+	createUser := func(email string) error {
+		if existing[email] {
+			switch onConflict {
+			case bulk.OnConflictSkip, bulk.OnConflictUpdate:
+				format.PrintInfo("skipping existing user '%s'", email)
+				return nil
+			case bulk.OnConflictFail:
+				return fmt.Errorf("user already exists")
+			}
+		}
+
+		if dryRun {
+			format.PrintInfo("(dry-run) would create user '%s'", email)
+			return nil
+		}
+
+		var password string
+		var err error
+		if generatePasswords {
+			password, err = prompt.GeneratePassword(20)
+		} else {
+			password, err = prompt.Password(fmt.Sprintf("Password for new user %s: ", email))
+		}
+		if err != nil {
+			return err
+		}
+
+		hash := sha256.Sum256([]byte(password))
+		_, err = client.ExecuteAuthProcedure("CreateNewUser", map[string]interface{}{
+			"NewUserEmail": email,
+			"NewUserHash":  fmt.Sprintf("0x%x", hash[:]),
+		}, adminEmail, adminPassword)
+		if err != nil {
+			return err
+		}
+
+		if generatePasswords {
+			fmt.Printf("%s\t%s\n", email, password)
+		}
+		format.PrintSuccess("✓ User '%s' created", email)
+		return nil
+	}
+
+	emails := make([]string, 0, len(records))
+	for _, record := range records {
+		email, _ := record["email"].(string)
+		if email == "" {
+			cliutil.Fatal(cmd, fmt.Errorf("record has no 'email' field: %v", record))
+			return
+		}
+		emails = append(emails, email)
+	}
+
+	effectiveParallelism := parallelism
+	if !generatePasswords {
+		effectiveParallelism = 1
+	}
+	results := bulk.RunPool(emails, effectiveParallelism, createUser)
+
+	if reportPath != "" {
+		if err := bulk.WriteReport(reportPath, results); err != nil {
+			cliutil.Fatal(cmd, err)
+			return
+		}
+	}
+
+	cliutil.Fatal(cmd, bulk.Summarize(results))
+}
+
+func loadExistingUserEmails(client *api.Client) (map[string]bool, error) {
+	response, err := client.ExecuteStoredProcedure("GetAllCompanyUsers", map[string]interface{}{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing users: %w", err)
+	}
+
+	emails := make(map[string]bool, len(response.Data))
+	for _, user := range response.Data {
+		if email, ok := user["email"].(string); ok {
+			emails[email] = true
+		}
+	}
+	return emails, nil
+}
+
+func init() {
+	userExportCmd.Flags().StringP("file", "f", "-", "Output file (defaults to stdout)")
+
+	userImportCmd.Flags().String("on-conflict", string(bulk.OnConflictFail), "How to handle users that already exist: skip, update, or fail")
+	userImportCmd.Flags().Bool("generate-passwords", false, "Generate a random password per user instead of prompting, printed once to stdout")
+	userImportCmd.Flags().Bool("dry-run", false, "Print what would happen without making any changes")
+	userImportCmd.Flags().String("report", "", "Write a per-record JSONL report to this file")
+	userImportCmd.Flags().Int("parallelism", 1, "Number of concurrent import workers (only honored with --generate-passwords)")
+
+	userCmd.AddCommand(userExportCmd)
+	userCmd.AddCommand(userImportCmd)
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/rediacc/cli/internal/api"
+	"github.com/rediacc/cli/internal/cliutil"
 	"github.com/rediacc/cli/internal/config"
 	"github.com/rediacc/cli/internal/format"
 )
@@ -24,7 +25,7 @@ var createCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new company",
 	Long:  "Create a new company with specified name and admin email",
-	RunE:  runCreate,
+	Run:   runCreate,
 }
 
 // infoCmd shows company information
@@ -32,7 +33,7 @@ var infoCmd = &cobra.Command{
 	Use:   "info",
 	Short: "Show company information",
 	Long:  "Display detailed information about the current company",
-	RunE:  runInfo,
+	Run:   runInfo,
 }
 
 // usersCmd manages company users
@@ -47,7 +48,7 @@ var usersListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List company users",
 	Long:  "List all users in the current company",
-	RunE:  runUsersList,
+	Run:   runUsersList,
 }
 
 // limitsCmd shows resource limits
@@ -55,7 +56,7 @@ var limitsCmd = &cobra.Command{
 	Use:   "limits",
 	Short: "Show resource limits",
 	Long:  "Display the current company's resource limits",
-	RunE:  runLimits,
+	Run:   runLimits,
 }
 
 // vaultCmd manages company vault
@@ -70,7 +71,7 @@ var vaultGetCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get vault data",
 	Long:  "Retrieve company secure data from vault",
-	RunE:  runVaultGet,
+	Run:   runVaultGet,
 }
 
 // vaultUpdateCmd updates vault data
@@ -78,7 +79,7 @@ var vaultUpdateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update vault data",
 	Long:  "Update company secure data in vault",
-	RunE:  runVaultUpdate,
+	Run:   runVaultUpdate,
 }
 
 // subscriptionCmd shows subscription info
@@ -86,16 +87,17 @@ var subscriptionCmd = &cobra.Command{
 	Use:   "subscription",
 	Short: "Show subscription information",
 	Long:  "Display the current company's subscription details",
-	RunE:  runSubscription,
+	Run:   runSubscription,
 }
 
-func runCreate(cmd *cobra.Command, args []string) error {
+func runCreate(cmd *cobra.Command, args []string) {
 	name, _ := cmd.Flags().GetString("name")
 	adminEmail, _ := cmd.Flags().GetString("admin-email")
 	adminPassword, _ := cmd.Flags().GetString("admin-password")
 
 	if name == "" || adminEmail == "" || adminPassword == "" {
-		return fmt.Errorf("company name, admin email, and admin password are required")
+		cliutil.Fatal(cmd, fmt.Errorf("company name, admin email, and admin password are required"))
+		return
 	}
 
 	cfg := config.Get()
@@ -107,146 +109,142 @@ func runCreate(cmd *cobra.Command, args []string) error {
 	}
 
 	// Use auth procedure since it's protected and needs email/password
-	response, err := client.ExecuteAuthProcedure("CreateNewCompany", params, adminEmail, adminPassword)
+	_, err = client.ExecuteAuthProcedure("CreateNewCompany", params, adminEmail, adminPassword)
 	if err != nil {
-		return fmt.Errorf("failed to create company: %w", err)
+		cliutil.Fatal(cmd, fmt.Errorf("failed to create company: %w", err))
+		return
 	}
 
-	if response.Success {
-		format.PrintSuccess("✓ Company '%s' created successfully", name)
-		format.PrintSuccess("✓ Admin user '%s' created", adminEmail)
-		format.PrintInfo("Check email for activation code to activate the account")
-		return nil
-	}
-
-	return fmt.Errorf("failed to create company: %s", response.Error)
+	format.PrintSuccess("✓ Company '%s' created successfully", name)
+	format.PrintSuccess("✓ Admin user '%s' created", adminEmail)
+	format.PrintInfo("Check email for activation code to activate the account")
 }
 
-func runInfo(cmd *cobra.Command, args []string) error {
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+func runInfo(cmd *cobra.Command, args []string) {
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
 
 	response, err := client.ExecuteStoredProcedure("GetUserCompanyDetails", map[string]interface{}{})
 	if err != nil {
-		return fmt.Errorf("failed to get company info: %w", err)
+		cliutil.Fatal(cmd, fmt.Errorf("failed to get company info: %w", err))
+		return
 	}
 
-	if response.Success {
-		if len(response.Data) == 0 {
-			fmt.Println("No company information found")
-			return nil
-		}
-		return format.Print(response.Data[0])
+	if len(response.Data) == 0 {
+		fmt.Println("No company information found")
+		return
 	}
-
-	return fmt.Errorf("failed to get company info: %s", response.Error)
+	cliutil.Fatal(cmd, format.Print(response.Data[0]))
 }
 
-func runUsersList(cmd *cobra.Command, args []string) error {
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+func runUsersList(cmd *cobra.Command, args []string) {
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
 
 	response, err := client.ExecuteStoredProcedure("GetAllCompanyUsers", map[string]interface{}{})
 	if err != nil {
-		return fmt.Errorf("failed to list company users: %w", err)
+		cliutil.Fatal(cmd, fmt.Errorf("failed to list company users: %w", err))
+		return
 	}
 
-	if response.Success {
-		if len(response.Data) == 0 {
-			fmt.Println("No users found")
-			return nil
-		}
-		return format.Print(response.Data)
+	if len(response.Data) == 0 {
+		fmt.Println("No users found")
+		return
 	}
-
-	return fmt.Errorf("failed to list company users: %s", response.Error)
+	cliutil.Fatal(cmd, format.Print(response.Data))
 }
 
-func runLimits(cmd *cobra.Command, args []string) error {
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+func runLimits(cmd *cobra.Command, args []string) {
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
 
 	response, err := client.ExecuteStoredProcedure("GetCompanyResourceLimits", map[string]interface{}{})
 	if err != nil {
-		return fmt.Errorf("failed to get resource limits: %w", err)
+		cliutil.Fatal(cmd, fmt.Errorf("failed to get resource limits: %w", err))
+		return
 	}
 
-	if response.Success {
-		if len(response.Data) == 0 {
-			fmt.Println("No resource limits found")
-			return nil
-		}
-		return format.Print(response.Data[0])
+	if len(response.Data) == 0 {
+		fmt.Println("No resource limits found")
+		return
 	}
-
-	return fmt.Errorf("failed to get resource limits: %s", response.Error)
+	cliutil.Fatal(cmd, format.Print(response.Data[0]))
 }
 
-func runVaultGet(cmd *cobra.Command, args []string) error {
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+func runVaultGet(cmd *cobra.Command, args []string) {
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
 
 	response, err := client.ExecuteStoredProcedure("GetCompanySecureData", map[string]interface{}{})
 	if err != nil {
-		return fmt.Errorf("failed to get vault data: %w", err)
+		cliutil.Fatal(cmd, fmt.Errorf("failed to get vault data: %w", err))
+		return
 	}
 
-	if response.Success {
-		if len(response.Data) == 0 {
-			fmt.Println("No vault data found")
-			return nil
-		}
-		return format.Print(response.Data[0])
+	if len(response.Data) == 0 {
+		fmt.Println("No vault data found")
+		return
 	}
-
-	return fmt.Errorf("failed to get vault data: %s", response.Error)
+	cliutil.Fatal(cmd, format.Print(response.Data[0]))
 }
 
-func runVaultUpdate(cmd *cobra.Command, args []string) error {
+func runVaultUpdate(cmd *cobra.Command, args []string) {
 	data, _ := cmd.Flags().GetString("data")
 
 	if data == "" {
-		return fmt.Errorf("vault data is required")
+		cliutil.Fatal(cmd, fmt.Errorf("vault data is required"))
+		return
 	}
 
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
 
 	params := map[string]interface{}{
 		"data": data,
 	}
 
-	response, err := client.ExecuteStoredProcedure("UpdateCompanySecureData", params)
+	_, err = client.ExecuteStoredProcedure("UpdateCompanySecureData", params)
 	if err != nil {
-		return fmt.Errorf("failed to update vault data: %w", err)
-	}
-
-	if response.Success {
-		format.PrintSuccess("✓ Company vault data updated successfully")
-		return nil
+		cliutil.Fatal(cmd, fmt.Errorf("failed to update vault data: %w", err))
+		return
 	}
 
-	return fmt.Errorf("failed to update vault data: %s", response.Error)
+	format.PrintSuccess("✓ Company vault data updated successfully")
 }
 
-func runSubscription(cmd *cobra.Command, args []string) error {
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+func runSubscription(cmd *cobra.Command, args []string) {
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
 
 	response, err := client.ExecuteStoredProcedure("GetSubscriptionDetails", map[string]interface{}{})
 	if err != nil {
-		return fmt.Errorf("failed to get subscription details: %w", err)
+		cliutil.Fatal(cmd, fmt.Errorf("failed to get subscription details: %w", err))
+		return
 	}
 
-	if response.Success {
-		if len(response.Data) == 0 {
-			fmt.Println("No subscription information found")
-			return nil
-		}
-		return format.Print(response.Data[0])
+	if len(response.Data) == 0 {
+		fmt.Println("No subscription information found")
+		return
 	}
-
-	return fmt.Errorf("failed to get subscription details: %s", response.Error)
+	cliutil.Fatal(cmd, format.Print(response.Data[0]))
 }
 
 func init() {
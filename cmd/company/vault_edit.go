@@ -0,0 +1,214 @@
+package company
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rediacc/cli/internal/api"
+	"github.com/rediacc/cli/internal/cliutil"
+	"github.com/rediacc/cli/internal/config"
+	"github.com/rediacc/cli/internal/crypto"
+	"github.com/rediacc/cli/internal/format"
+	"github.com/rediacc/cli/internal/prompt"
+)
+
+// vaultEditCmd pulls the vault, opens it in $EDITOR against an
+// offline-encrypted cache, and pushes it back only on confirmation.
+var vaultEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Edit vault data locally in $EDITOR",
+	Long: `Pull the current vault data, decrypt/cache it at ~/.rediacc-cli/vault.age
+so it stays readable offline, open it in $EDITOR, and push the result back
+to UpdateCompanySecureData after showing a redacted diff and confirming.
+
+The cache is encrypted either to an age identity file (set via
+"config set vault.identity <path>") or, if none is configured, to a
+passphrase entered interactively.`,
+	Run: runVaultEdit,
+}
+
+func runVaultEdit(cmd *cobra.Command, args []string) {
+	cfg := config.Get()
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	response, err := client.ExecuteStoredProcedure("GetCompanySecureData", map[string]interface{}{})
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to get vault data: %w", err))
+		return
+	}
+	if len(response.Data) == 0 {
+		cliutil.Fatal(cmd, fmt.Errorf("no vault data found"))
+		return
+	}
+
+	original, err := json.MarshalIndent(response.Data[0], "", "  ")
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to encode vault data: %w", err))
+		return
+	}
+
+	passphrase, err := vaultPassphrase(cfg.Vault.Identity)
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	if err := crypto.WriteVaultCache(original, passphrase, cfg.Vault.Identity); err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to cache vault data offline: %w", err))
+		return
+	}
+
+	edited, err := editInEditor(original)
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	if !json.Valid(edited) {
+		cliutil.Fatal(cmd, fmt.Errorf("edited vault data is not valid JSON, aborting"))
+		return
+	}
+
+	if string(edited) == string(original) {
+		format.PrintInfo("No changes made")
+		return
+	}
+
+	fmt.Println(redactedDiff(original, edited))
+
+	if !confirm("Push updated vault data to the server? [y/N]: ") {
+		format.PrintInfo("Aborted, local cache left at ~/.rediacc-cli/vault.age")
+		return
+	}
+
+	_, err = client.ExecuteStoredProcedure("UpdateCompanySecureData", map[string]interface{}{
+		"data": string(edited),
+	})
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to update vault data: %w", err))
+		return
+	}
+
+	if err := crypto.WriteVaultCache(edited, passphrase, cfg.Vault.Identity); err != nil {
+		format.PrintWarning("vault updated, but failed to refresh the local cache: %s", err)
+		return
+	}
+
+	format.PrintSuccess("✓ Company vault data updated successfully")
+}
+
+// vaultPassphrase returns "" when an identity file is configured (age
+// public-key mode needs no passphrase), otherwise it prompts for one.
+func vaultPassphrase(identityPath string) (string, error) {
+	if identityPath != "" {
+		return "", nil
+	}
+	return prompt.Password("Vault cache passphrase: ")
+}
+
+// editInEditor writes original to a temp file, opens $EDITOR on it (falling
+// back to vi), and returns the file's contents afterwards.
+func editInEditor(original []byte) ([]byte, error) {
+	tmp, err := os.CreateTemp("", "rediacc-vault-*.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	path := tmp.Name()
+	defer os.Remove(path)
+
+	if _, err := tmp.Write(original); err != nil {
+		tmp.Close()
+		return nil, fmt.Errorf("failed to write temp file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close temp file: %w", err)
+	}
+
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+
+	c := exec.Command(editor, path)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	if err := c.Run(); err != nil {
+		return nil, fmt.Errorf("editor exited with an error: %w", err)
+	}
+
+	edited, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read edited file: %w", err)
+	}
+	return edited, nil
+}
+
+// redactedDiff summarizes which top-level keys were added, removed, or
+// changed, without printing any secret values.
+func redactedDiff(original, edited []byte) string {
+	var before, after map[string]interface{}
+	if err := json.Unmarshal(original, &before); err != nil {
+		return "(unable to diff: original is not a JSON object)"
+	}
+	if err := json.Unmarshal(edited, &after); err != nil {
+		return "(unable to diff: edited is not a JSON object)"
+	}
+
+	keys := make(map[string]struct{}, len(before)+len(after))
+	for k := range before {
+		keys[k] = struct{}{}
+	}
+	for k := range after {
+		keys[k] = struct{}{}
+	}
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var b strings.Builder
+	b.WriteString("Changes (values redacted):\n")
+	for _, k := range sorted {
+		beforeVal, hadBefore := before[k]
+		afterVal, hasAfter := after[k]
+		switch {
+		case !hadBefore:
+			fmt.Fprintf(&b, "  + %s\n", k)
+		case !hasAfter:
+			fmt.Fprintf(&b, "  - %s\n", k)
+		case fmt.Sprintf("%v", beforeVal) != fmt.Sprintf("%v", afterVal):
+			fmt.Fprintf(&b, "  ~ %s\n", k)
+		}
+	}
+	return b.String()
+}
+
+// confirm prompts label on stdout and reports whether the user answered
+// affirmatively (y/yes, case-insensitive); anything else, including a bare
+// Enter, is treated as "no".
+func confirm(label string) bool {
+	fmt.Print(label)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil {
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+func init() {
+	vaultCmd.AddCommand(vaultEditCmd)
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// completionCmd generates shell completion scripts. Dynamic completion of
+// team names and user emails (teams delete/rename/members, auth user
+// info/activate/deactivate/update-password) is wired via ValidArgsFunction
+// on those commands and backed by internal/completion.
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish|powershell]",
+	Short: "Generate shell completion scripts",
+	Long: `Generate a shell completion script for rediacc.
+
+To load completions:
+
+Bash:
+  $ source <(rediacc completion bash)
+
+Zsh:
+  $ rediacc completion zsh > "${fpath[1]}/_rediacc"
+
+Fish:
+  $ rediacc completion fish | source
+
+PowerShell:
+  PS> rediacc completion powershell | Out-String | Invoke-Expression
+`,
+	DisableFlagsInUseLine: true,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		switch args[0] {
+		case "bash":
+			return cmd.Root().GenBashCompletion(os.Stdout)
+		case "zsh":
+			return cmd.Root().GenZshCompletion(os.Stdout)
+		case "fish":
+			return cmd.Root().GenFishCompletion(os.Stdout, true)
+		case "powershell":
+			return cmd.Root().GenPowerShellCompletionWithDesc(os.Stdout)
+		default:
+			return nil
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/rediacc/cli/internal/cliutil"
 	appConfig "github.com/rediacc/cli/internal/config"
 )
 
@@ -25,7 +26,7 @@ var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List current configuration",
 	Long:  "Display the current configuration settings",
-	RunE:  runList,
+	Run:   runList,
 }
 
 // getCmd gets a specific configuration value
@@ -34,7 +35,7 @@ var getCmd = &cobra.Command{
 	Short: "Get a configuration value",
 	Long:  "Get the value of a specific configuration key",
 	Args:  cobra.ExactArgs(1),
-	RunE:  runGet,
+	Run:   runGet,
 }
 
 // setCmd sets a configuration value
@@ -43,7 +44,7 @@ var setCmd = &cobra.Command{
 	Short: "Set a configuration value",
 	Long:  "Set the value of a specific configuration key",
 	Args:  cobra.ExactArgs(2),
-	RunE:  runSet,
+	Run:   runSet,
 }
 
 // pathCmd shows the configuration file path
@@ -51,13 +52,16 @@ var pathCmd = &cobra.Command{
 	Use:   "path",
 	Short: "Show configuration file path",
 	Long:  "Display the path to the configuration file",
-	RunE:  runPath,
+	Run:   runPath,
 }
 
-func runList(cmd *cobra.Command, args []string) error {
+func runList(cmd *cobra.Command, args []string) {
 	cfg := appConfig.Get()
-	
+
 	fmt.Println("Current configuration:")
+	if active := appConfig.ActiveProfileName(); active != "" {
+		fmt.Printf("  Active Profile: %s\n", active)
+	}
 	fmt.Printf("  Server URL: %s\n", cfg.Server.URL)
 	fmt.Printf("  Server Timeout: %s\n", cfg.Server.Timeout)
 	fmt.Printf("  Auth Email: %s\n", cfg.Auth.Email)
@@ -70,53 +74,50 @@ func runList(cmd *cobra.Command, args []string) error {
 	fmt.Printf("  Default Datastore Size: %s\n", cfg.Jobs.DefaultDatastoreSize)
 	fmt.Printf("  SSH Key Path: %s\n", cfg.Jobs.SSHKeyPath)
 	fmt.Printf("  Number of Machines: %d\n", len(cfg.Jobs.Machines))
-	
-	return nil
 }
 
-func runGet(cmd *cobra.Command, args []string) error {
+func runGet(cmd *cobra.Command, args []string) {
 	key := args[0]
 	value := viper.Get(key)
-	
+
 	if value == nil {
-		return fmt.Errorf("configuration key '%s' not found", key)
+		cliutil.Fatal(cmd, fmt.Errorf("configuration key '%s' not found", key))
+		return
 	}
-	
+
 	fmt.Printf("%s: %v\n", key, value)
-	return nil
 }
 
-func runSet(cmd *cobra.Command, args []string) error {
+func runSet(cmd *cobra.Command, args []string) {
 	key := args[0]
 	value := args[1]
-	
+
 	viper.Set(key, value)
-	
+
 	if err := viper.WriteConfig(); err != nil {
-		return fmt.Errorf("failed to save configuration: %w", err)
+		cliutil.Fatal(cmd, fmt.Errorf("failed to save configuration: %w", err))
+		return
 	}
-	
+
 	fmt.Printf("Set %s = %s\n", key, value)
-	return nil
 }
 
-func runPath(cmd *cobra.Command, args []string) error {
+func runPath(cmd *cobra.Command, args []string) {
 	home, err := os.UserHomeDir()
 	if err != nil {
-		return fmt.Errorf("could not get home directory: %w", err)
+		cliutil.Fatal(cmd, fmt.Errorf("could not get home directory: %w", err))
+		return
 	}
-	
+
 	configPath := filepath.Join(home, ".rediacc-cli.yaml")
 	fmt.Println(configPath)
-	
+
 	// Check if file exists
 	if _, err := os.Stat(configPath); os.IsNotExist(err) {
 		fmt.Println("(file does not exist)")
 	} else {
 		fmt.Println("(file exists)")
 	}
-	
-	return nil
 }
 
 func init() {
@@ -125,4 +126,6 @@ func init() {
 	ConfigCmd.AddCommand(getCmd)
 	ConfigCmd.AddCommand(setCmd)
 	ConfigCmd.AddCommand(pathCmd)
+	ConfigCmd.AddCommand(useCmd)
+	ConfigCmd.AddCommand(profileCmd)
 }
@@ -0,0 +1,198 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/rediacc/cli/internal/cliutil"
+	appConfig "github.com/rediacc/cli/internal/config"
+	"github.com/rediacc/cli/internal/format"
+)
+
+// useCmd switches the persisted active profile
+var useCmd = &cobra.Command{
+	Use:   "use <profile-name>",
+	Short: "Switch the active config profile",
+	Long:  "Set the named profile as active for future commands. Overridden for a single invocation by --profile or REDIACC_PROFILE",
+	Args:  cobra.ExactArgs(1),
+	Run:   runUse,
+}
+
+// profileCmd groups profile management commands
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage config profiles",
+	Long:  "Commands for managing named environment profiles (e.g. dev, staging, prod) stored under `profiles:` in the config file",
+}
+
+// profileListCmd lists configured profiles
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List configured profiles",
+	Long:  "List all named profiles and show which one is currently active",
+	Run:   runProfileList,
+}
+
+// profileCreateCmd creates or overwrites a profile. "add" is aliased here
+// rather than given its own command, since cobra subcommands can only have
+// one parent: a `config profile use` alias of the top-level `config use`
+// isn't possible the same way, so that alias doesn't exist.
+var profileCreateCmd = &cobra.Command{
+	Use:     "create <profile-name>",
+	Aliases: []string{"add"},
+	Short:   "Create or update a profile",
+	Long:    "Create a named profile, or overwrite an existing one, from the given flags. Fields left unset fall back to the base configuration",
+	Args:    cobra.ExactArgs(1),
+	Run:     runProfileCreate,
+}
+
+// profileDeleteCmd removes a profile
+var profileDeleteCmd = &cobra.Command{
+	Use:   "delete <profile-name>",
+	Short: "Delete a profile",
+	Long:  "Remove a named profile. Clears the active profile selection if it was the one deleted",
+	Args:  cobra.ExactArgs(1),
+	Run:   runProfileDelete,
+}
+
+// profileDiffCmd compares two profiles (or a profile against the base
+// config, when one side is omitted).
+var profileDiffCmd = &cobra.Command{
+	Use:   "diff <profile-a> [profile-b]",
+	Short: "Show how two profiles differ",
+	Long:  "Compare two profiles field by field, or a profile against the base configuration when profile-b is omitted",
+	Args:  cobra.RangeArgs(1, 2),
+	Run:   runProfileDiff,
+}
+
+// profileCopyCmd duplicates a profile under a new name.
+var profileCopyCmd = &cobra.Command{
+	Use:   "copy <src> <dst>",
+	Short: "Copy a profile to a new name",
+	Long:  "Duplicate an existing profile's settings under a new name, overwriting dst if it already exists",
+	Args:  cobra.ExactArgs(2),
+	Run:   runProfileCopy,
+}
+
+func runUse(cmd *cobra.Command, args []string) {
+	name := args[0]
+
+	if err := appConfig.UseProfile(name); err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	format.PrintSuccess("✓ Active profile set to '%s'", name)
+}
+
+func runProfileList(cmd *cobra.Command, args []string) {
+	names := appConfig.ListProfiles()
+	if len(names) == 0 {
+		fmt.Println("No profiles configured")
+		return
+	}
+
+	active := appConfig.ActiveProfileName()
+	for _, name := range names {
+		if name == active {
+			fmt.Printf("* %s (active)\n", name)
+		} else {
+			fmt.Printf("  %s\n", name)
+		}
+	}
+}
+
+func runProfileCreate(cmd *cobra.Command, args []string) {
+	name := args[0]
+
+	serverURL, _ := cmd.Flags().GetString("server-url")
+	timeout, _ := cmd.Flags().GetString("timeout")
+	email, _ := cmd.Flags().GetString("email")
+	outputFormat, _ := cmd.Flags().GetString("output")
+
+	profile := appConfig.ProfileConfig{
+		Server: appConfig.ServerConfig{
+			URL:     serverURL,
+			Timeout: timeout,
+		},
+		Auth: appConfig.AuthConfig{
+			Email: email,
+		},
+		Format: appConfig.FormatConfig{
+			Default: outputFormat,
+		},
+	}
+
+	if err := appConfig.CreateProfile(name, profile); err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	format.PrintSuccess("✓ Profile '%s' saved", name)
+}
+
+func runProfileDelete(cmd *cobra.Command, args []string) {
+	name := args[0]
+
+	if err := appConfig.DeleteProfile(name); err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	format.PrintSuccess("✓ Profile '%s' deleted", name)
+}
+
+func runProfileDiff(cmd *cobra.Command, args []string) {
+	a := args[0]
+	b := ""
+	if len(args) > 1 {
+		b = args[1]
+	}
+
+	diffs, err := appConfig.DiffProfiles(a, b)
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	if len(diffs) == 0 {
+		fmt.Printf("profiles '%s' and '%s' match\n", profileLabel(a), profileLabel(b))
+		return
+	}
+	for _, d := range diffs {
+		fmt.Println(d)
+	}
+}
+
+func runProfileCopy(cmd *cobra.Command, args []string) {
+	src, dst := args[0], args[1]
+
+	if err := appConfig.CopyProfile(src, dst); err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	format.PrintSuccess("✓ Profile '%s' copied to '%s'", src, dst)
+}
+
+// profileLabel renders a profile name for diff output, since "" means the
+// base config rather than an actual profile.
+func profileLabel(name string) string {
+	if name == "" {
+		return "(base)"
+	}
+	return name
+}
+
+func init() {
+	profileCreateCmd.Flags().String("server-url", "", "Server URL for this profile, e.g. https://staging.rediacc.example.com")
+	profileCreateCmd.Flags().String("timeout", "", "Server request timeout for this profile, e.g. 30s")
+	profileCreateCmd.Flags().String("email", "", "Default auth email for this profile")
+	profileCreateCmd.Flags().String("output", "", "Default output format for this profile")
+
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileCreateCmd)
+	profileCmd.AddCommand(profileDeleteCmd)
+	profileCmd.AddCommand(profileDiffCmd)
+	profileCmd.AddCommand(profileCopyCmd)
+}
@@ -5,7 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/rediacc/cli/internal/api"
-	"github.com/rediacc/cli/internal/config"
+	"github.com/rediacc/cli/internal/cliutil"
 	"github.com/rediacc/cli/internal/format"
 )
 
@@ -14,7 +14,7 @@ var InfraCmd = &cobra.Command{
 	Use:   "infra",
 	Short: "Infrastructure management commands",
 	Long: `Infrastructure management commands for Rediacc CLI.
-	
+
 This command group includes region, bridge, and machine management operations.`,
 }
 
@@ -30,7 +30,7 @@ var regionsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List regions",
 	Long:  "List all regions in the company",
-	RunE:  runRegionsList,
+	Run:   runRegionsList,
 }
 
 // regionsCreateCmd creates a region
@@ -39,7 +39,7 @@ var regionsCreateCmd = &cobra.Command{
 	Short: "Create a region",
 	Long:  "Create a new infrastructure region",
 	Args:  cobra.ExactArgs(1),
-	RunE:  runRegionsCreate,
+	Run:   runRegionsCreate,
 }
 
 // machinesCmd manages machines
@@ -55,57 +55,59 @@ var machinesListCmd = &cobra.Command{
 	Short: "List machines",
 	Long:  "List all machines for a specific team",
 	Args:  cobra.ExactArgs(1),
-	RunE:  runMachinesList,
+	Run:   runMachinesList,
 }
 
-func runRegionsList(cmd *cobra.Command, args []string) error {
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+func runRegionsList(cmd *cobra.Command, args []string) {
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
 
 	response, err := client.ExecuteStoredProcedure("GetAllCompanyRegions", map[string]interface{}{})
 	if err != nil {
-		return fmt.Errorf("failed to list regions: %w", err)
+		cliutil.Fatal(cmd, fmt.Errorf("failed to list regions: %w", err))
+		return
 	}
 
-	if response.Success {
-		if len(response.Data) == 0 {
-			fmt.Println("No regions found")
-			return nil
-		}
-		return format.Print(response.Data)
+	if len(response.Data) == 0 {
+		fmt.Println("No regions found")
+		return
 	}
-
-	return fmt.Errorf("failed to list regions: %s", response.Error)
+	cliutil.Fatal(cmd, format.Print(response.Data))
 }
 
-func runRegionsCreate(cmd *cobra.Command, args []string) error {
+func runRegionsCreate(cmd *cobra.Command, args []string) {
 	name := args[0]
 
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
 
 	params := map[string]interface{}{
 		"name": name,
 	}
 
-	response, err := client.ExecuteStoredProcedure("CreateRegion", params)
+	_, err = client.ExecuteStoredProcedure("CreateRegion", params)
 	if err != nil {
-		return fmt.Errorf("failed to create region: %w", err)
+		cliutil.Fatal(cmd, fmt.Errorf("failed to create region: %w", err))
+		return
 	}
 
-	if response.Success {
-		format.PrintSuccess("âœ“ Region '%s' created successfully", name)
-		return nil
-	}
-
-	return fmt.Errorf("failed to create region: %s", response.Error)
+	format.PrintSuccess("âœ“ Region '%s' created successfully", name)
 }
 
-func runMachinesList(cmd *cobra.Command, args []string) error {
+func runMachinesList(cmd *cobra.Command, args []string) {
 	team := args[0]
 
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
 
 	params := map[string]interface{}{
 		"team": team,
@@ -113,18 +115,15 @@ func runMachinesList(cmd *cobra.Command, args []string) error {
 
 	response, err := client.ExecuteStoredProcedure("GetTeamMachines", params)
 	if err != nil {
-		return fmt.Errorf("failed to list machines: %w", err)
+		cliutil.Fatal(cmd, fmt.Errorf("failed to list machines: %w", err))
+		return
 	}
 
-	if response.Success {
-		if len(response.Data) == 0 {
-			fmt.Printf("No machines found for team '%s'\n", team)
-			return nil
-		}
-		return format.Print(response.Data)
+	if len(response.Data) == 0 {
+		fmt.Printf("No machines found for team '%s'\n", team)
+		return
 	}
-
-	return fmt.Errorf("failed to list machines: %s", response.Error)
+	cliutil.Fatal(cmd, format.Print(response.Data))
 }
 
 func init() {
@@ -0,0 +1,220 @@
+package jobs
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/rediacc/cli/internal/api"
+	"github.com/rediacc/cli/internal/cliutil"
+	"github.com/rediacc/cli/internal/format"
+)
+
+// uploadChunkSize and downloadChunkSize bound how much file data crosses
+// the wire (base64-encoded, inside a single stored procedure call) per
+// chunk of `jobs file push`/`pull`.
+const (
+	uploadChunkSize   = 4 << 20
+	downloadChunkSize = 4 << 20
+)
+
+// fileCmd manages files on a machine
+var fileCmd = &cobra.Command{
+	Use:   "file",
+	Short: "File transfer commands",
+	Long:  "Push, pull, and list files on a machine",
+}
+
+var filePushCmd = &cobra.Command{
+	Use:   "push <machine> <local-path> <remote-path>",
+	Short: "Upload a file to a machine",
+	Long:  "Upload a file to a machine in chunks, resuming from GetFileUploadStatus if a previous attempt was interrupted",
+	Args:  cobra.ExactArgs(3),
+	Run:   runFilePush,
+}
+
+var filePullCmd = &cobra.Command{
+	Use:   "pull <machine> <remote-path> <local-path>",
+	Short: "Download a file from a machine",
+	Long:  "Download a file from a machine in chunks",
+	Args:  cobra.ExactArgs(3),
+	Run:   runFilePull,
+}
+
+var fileLsCmd = &cobra.Command{
+	Use:   "ls <machine> <path>",
+	Short: "List files on a machine",
+	Long:  "List the contents of a directory on a machine",
+	Args:  cobra.ExactArgs(2),
+	Run:   runFileLs,
+}
+
+func runFilePush(cmd *cobra.Command, args []string) {
+	machine, localPath, remotePath := args[0], args[1], args[2]
+
+	f, err := os.Open(localPath)
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to open %s: %w", localPath, err))
+		return
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to stat %s: %w", localPath, err))
+		return
+	}
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	offset := resumeOffset(client, machine, remotePath)
+	if offset > 0 {
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			cliutil.Fatal(cmd, fmt.Errorf("failed to resume %s at byte %d: %w", localPath, offset, err))
+			return
+		}
+		fmt.Fprintf(os.Stderr, "resuming upload at byte %d of %d\n", offset, info.Size())
+	}
+
+	buf := make([]byte, uploadChunkSize)
+	for offset < info.Size() {
+		n, readErr := f.Read(buf)
+		if n > 0 {
+			final := offset+int64(n) >= info.Size()
+			_, err := client.ExecuteStoredProcedure("UploadFileChunk", map[string]interface{}{
+				"machine": machine,
+				"path":    remotePath,
+				"offset":  offset,
+				"data":    base64.StdEncoding.EncodeToString(buf[:n]),
+				"final":   final,
+			})
+			if err != nil {
+				cliutil.Fatal(cmd, fmt.Errorf("upload %s to %s:%s failed at byte %d (re-run to resume): %w", localPath, machine, remotePath, offset, err))
+				return
+			}
+			offset += int64(n)
+			fmt.Fprintf(os.Stderr, "\r%d/%d bytes", offset, info.Size())
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			cliutil.Fatal(cmd, fmt.Errorf("failed to read %s: %w", localPath, readErr))
+			return
+		}
+	}
+
+	fmt.Fprintln(os.Stderr)
+	format.PrintSuccess("✓ Uploaded %s to %s:%s", localPath, machine, remotePath)
+}
+
+// resumeOffset asks the middleware how much of remotePath it already has
+// from a previous, interrupted `jobs file push`, so the upload can pick up
+// where it left off instead of starting over. Any error (including "no
+// upload in progress") is treated as "start from the beginning".
+func resumeOffset(client *api.Client, machine, remotePath string) int64 {
+	response, err := client.ExecuteStoredProcedure("GetFileUploadStatus", map[string]interface{}{
+		"machine": machine,
+		"path":    remotePath,
+	})
+	if err != nil || len(response.Data) == 0 {
+		return 0
+	}
+	offset, _ := response.Data[0]["offset"].(float64)
+	return int64(offset)
+}
+
+func runFilePull(cmd *cobra.Command, args []string) {
+	machine, remotePath, localPath := args[0], args[1], args[2]
+
+	out, err := os.Create(localPath)
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to create %s: %w", localPath, err))
+		return
+	}
+	defer out.Close()
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	var offset int64
+	for {
+		response, err := client.ExecuteStoredProcedure("DownloadFileChunk", map[string]interface{}{
+			"machine": machine,
+			"path":    remotePath,
+			"offset":  offset,
+			"length":  downloadChunkSize,
+		})
+		if err != nil {
+			cliutil.Fatal(cmd, fmt.Errorf("download %s:%s failed at byte %d: %w", machine, remotePath, offset, err))
+			return
+		}
+		if len(response.Data) == 0 {
+			break
+		}
+
+		row := response.Data[0]
+		encoded, _ := row["data"].(string)
+		chunk, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			cliutil.Fatal(cmd, fmt.Errorf("failed to decode chunk at byte %d: %w", offset, err))
+			return
+		}
+		if len(chunk) == 0 {
+			break
+		}
+
+		if _, err := out.Write(chunk); err != nil {
+			cliutil.Fatal(cmd, fmt.Errorf("failed to write %s: %w", localPath, err))
+			return
+		}
+		offset += int64(len(chunk))
+		fmt.Fprintf(os.Stderr, "\r%d bytes", offset)
+
+		if final, _ := row["final"].(bool); final {
+			break
+		}
+	}
+
+	fmt.Fprintln(os.Stderr)
+	format.PrintSuccess("✓ Downloaded %s:%s to %s", machine, remotePath, localPath)
+}
+
+func runFileLs(cmd *cobra.Command, args []string) {
+	machine, path := args[0], args[1]
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	response, err := client.ExecuteStoredProcedure("ListFiles", map[string]interface{}{
+		"machine": machine,
+		"path":    path,
+	})
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to list %s:%s: %w", machine, path, err))
+		return
+	}
+	if len(response.Data) == 0 {
+		fmt.Println("No files found")
+		return
+	}
+	cliutil.Fatal(cmd, format.Print(response.Data))
+}
+
+func init() {
+	fileCmd.AddCommand(filePushCmd)
+	fileCmd.AddCommand(filePullCmd)
+	fileCmd.AddCommand(fileLsCmd)
+}
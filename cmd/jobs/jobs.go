@@ -9,11 +9,15 @@ var JobsCmd = &cobra.Command{
 	Use:   "jobs",
 	Short: "Machine job management commands",
 	Long: `Machine job management commands for Rediacc CLI.
-	
+
 This command group includes machine operations, repository operations,
 plugin management, terminal access, and file operations.`,
 }
 
 func init() {
-	// TODO: Add subcommands for machine, repo, plugin operations
+	JobsCmd.AddCommand(machineCmd)
+	JobsCmd.AddCommand(repoCmd)
+	JobsCmd.AddCommand(pluginCmd)
+	JobsCmd.AddCommand(terminalCmd)
+	JobsCmd.AddCommand(fileCmd)
 }
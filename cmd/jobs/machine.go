@@ -0,0 +1,106 @@
+package jobs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/rediacc/cli/internal/api"
+	"github.com/rediacc/cli/internal/cliutil"
+	"github.com/rediacc/cli/internal/format"
+)
+
+// machineCmd manages the lifecycle of a running machine
+var machineCmd = &cobra.Command{
+	Use:   "machine",
+	Short: "Machine lifecycle commands",
+	Long:  "Start, stop, restart, and run commands on a machine",
+}
+
+var machineStartCmd = &cobra.Command{
+	Use:   "start <machine>",
+	Short: "Start a machine",
+	Long:  "Start a machine, waiting for the job to complete",
+	Args:  cobra.ExactArgs(1),
+	Run:   runMachineJob("StartMachine"),
+}
+
+var machineStopCmd = &cobra.Command{
+	Use:   "stop <machine>",
+	Short: "Stop a machine",
+	Long:  "Stop a machine, waiting for the job to complete",
+	Args:  cobra.ExactArgs(1),
+	Run:   runMachineJob("StopMachine"),
+}
+
+var machineRestartCmd = &cobra.Command{
+	Use:   "restart <machine>",
+	Short: "Restart a machine",
+	Long:  "Restart a machine, waiting for the job to complete",
+	Args:  cobra.ExactArgs(1),
+	Run:   runMachineJob("RestartMachine"),
+}
+
+var machineExecCmd = &cobra.Command{
+	Use:   "exec <machine> -- <command...>",
+	Short: "Run a command on a machine",
+	Long:  "Run a shell command on a machine and wait for the job to complete",
+	Args:  cobra.MinimumNArgs(2),
+	Run:   runMachineExec,
+}
+
+// runMachineJob returns a Run func that submits procedure with the
+// machine's alias as its only parameter, the shape shared by
+// start/stop/restart.
+func runMachineJob(procedure string) func(cmd *cobra.Command, args []string) {
+	return func(cmd *cobra.Command, args []string) {
+		machine := args[0]
+
+		client, err := api.NewAuthenticatedClient(cmd.Context())
+		if err != nil {
+			cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+			return
+		}
+
+		result, err := client.ExecuteJob(procedure, map[string]interface{}{"machine": machine})
+		if err != nil {
+			cliutil.Fatal(cmd, fmt.Errorf("%s %s: %w", strings.ToLower(strings.TrimSuffix(procedure, "Machine")), machine, err))
+			return
+		}
+
+		format.PrintSuccess("✓ %s (job %s)", result.Status, result.JobID)
+	}
+}
+
+func runMachineExec(cmd *cobra.Command, args []string) {
+	machine := args[0]
+	command := strings.Join(args[1:], " ")
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	result, err := client.ExecuteJob("ExecuteMachineCommand", map[string]interface{}{
+		"machine": machine,
+		"command": command,
+	})
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("exec on %s: %w", machine, err))
+		return
+	}
+
+	if len(result.Data) > 0 {
+		cliutil.Fatal(cmd, format.Print(result.Data))
+		return
+	}
+	format.PrintSuccess("✓ %s (job %s)", result.Status, result.JobID)
+}
+
+func init() {
+	machineCmd.AddCommand(machineStartCmd)
+	machineCmd.AddCommand(machineStopCmd)
+	machineCmd.AddCommand(machineRestartCmd)
+	machineCmd.AddCommand(machineExecCmd)
+}
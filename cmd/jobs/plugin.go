@@ -0,0 +1,114 @@
+package jobs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/rediacc/cli/internal/api"
+	"github.com/rediacc/cli/internal/cliutil"
+	"github.com/rediacc/cli/internal/format"
+)
+
+// pluginCmd manages plugins available to run on a machine
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Plugin management commands",
+	Long:  "List, install, and run plugins on a machine",
+}
+
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List available plugins",
+	Long:  "List plugins known to the middleware",
+	Run:   runPluginList,
+}
+
+var pluginInstallCmd = &cobra.Command{
+	Use:   "install <machine> <plugin>",
+	Short: "Install a plugin onto a machine",
+	Long:  "Install a plugin onto a machine, waiting for the job to complete",
+	Args:  cobra.ExactArgs(2),
+	Run:   runPluginInstall,
+}
+
+var pluginRunCmd = &cobra.Command{
+	Use:   "run <machine> <plugin> [-- args...]",
+	Short: "Run a plugin on a machine",
+	Long:  "Run an installed plugin on a machine, waiting for the job to complete",
+	Args:  cobra.MinimumNArgs(2),
+	Run:   runPluginRun,
+}
+
+func runPluginList(cmd *cobra.Command, args []string) {
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	response, err := client.ExecuteStoredProcedure("ListPlugins", map[string]interface{}{})
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to list plugins: %w", err))
+		return
+	}
+	if len(response.Data) == 0 {
+		fmt.Println("No plugins found")
+		return
+	}
+	cliutil.Fatal(cmd, format.Print(response.Data))
+}
+
+func runPluginInstall(cmd *cobra.Command, args []string) {
+	machine, plugin := args[0], args[1]
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	result, err := client.ExecuteJob("InstallPlugin", map[string]interface{}{
+		"machine": machine,
+		"plugin":  plugin,
+	})
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("install %s on %s: %w", plugin, machine, err))
+		return
+	}
+
+	format.PrintSuccess("✓ %s (job %s)", result.Status, result.JobID)
+}
+
+func runPluginRun(cmd *cobra.Command, args []string) {
+	machine, plugin := args[0], args[1]
+	pluginArgs := strings.Join(args[2:], " ")
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	result, err := client.ExecuteJob("RunPlugin", map[string]interface{}{
+		"machine": machine,
+		"plugin":  plugin,
+		"args":    pluginArgs,
+	})
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("run %s on %s: %w", plugin, machine, err))
+		return
+	}
+
+	if len(result.Data) > 0 {
+		cliutil.Fatal(cmd, format.Print(result.Data))
+		return
+	}
+	format.PrintSuccess("✓ %s (job %s)", result.Status, result.JobID)
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginListCmd)
+	pluginCmd.AddCommand(pluginInstallCmd)
+	pluginCmd.AddCommand(pluginRunCmd)
+}
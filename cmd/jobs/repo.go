@@ -0,0 +1,115 @@
+package jobs
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/rediacc/cli/internal/api"
+	"github.com/rediacc/cli/internal/cliutil"
+	"github.com/rediacc/cli/internal/format"
+)
+
+// repoCmd manages repositories deployed onto a machine
+var repoCmd = &cobra.Command{
+	Use:   "repo",
+	Short: "Repository operation commands",
+	Long:  "Clone, sync, and snapshot repositories on a machine",
+}
+
+var repoCloneCmd = &cobra.Command{
+	Use:   "clone <machine> <url> <path>",
+	Short: "Clone a repository onto a machine",
+	Long:  "Clone a repository onto a machine at the given path, waiting for the job to complete",
+	Args:  cobra.ExactArgs(3),
+	Run:   runRepoClone,
+}
+
+var repoSyncCmd = &cobra.Command{
+	Use:   "sync <machine> <path>",
+	Short: "Sync a repository on a machine",
+	Long:  "Pull the latest changes into a repository already cloned on a machine",
+	Args:  cobra.ExactArgs(2),
+	Run:   runRepoSync,
+}
+
+var repoSnapshotCmd = &cobra.Command{
+	Use:   "snapshot <machine> <path>",
+	Short: "Snapshot a repository on a machine",
+	Long:  "Take a datastore snapshot of a repository's working tree on a machine",
+	Args:  cobra.ExactArgs(2),
+	Run:   runRepoSnapshot,
+}
+
+func runRepoClone(cmd *cobra.Command, args []string) {
+	machine, url, path := args[0], args[1], args[2]
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	result, err := client.ExecuteJob("CloneRepository", map[string]interface{}{
+		"machine": machine,
+		"url":     url,
+		"path":    path,
+	})
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("clone %s on %s: %w", url, machine, err))
+		return
+	}
+
+	format.PrintSuccess("✓ %s (job %s)", result.Status, result.JobID)
+}
+
+func runRepoSync(cmd *cobra.Command, args []string) {
+	machine, path := args[0], args[1]
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	result, err := client.ExecuteJob("SyncRepository", map[string]interface{}{
+		"machine": machine,
+		"path":    path,
+	})
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("sync %s on %s: %w", path, machine, err))
+		return
+	}
+
+	format.PrintSuccess("✓ %s (job %s)", result.Status, result.JobID)
+}
+
+func runRepoSnapshot(cmd *cobra.Command, args []string) {
+	machine, path := args[0], args[1]
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	result, err := client.ExecuteJob("SnapshotRepository", map[string]interface{}{
+		"machine": machine,
+		"path":    path,
+	})
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("snapshot %s on %s: %w", path, machine, err))
+		return
+	}
+
+	if len(result.Data) > 0 {
+		cliutil.Fatal(cmd, format.Print(result.Data))
+		return
+	}
+	format.PrintSuccess("✓ %s (job %s)", result.Status, result.JobID)
+}
+
+func init() {
+	repoCmd.AddCommand(repoCloneCmd)
+	repoCmd.AddCommand(repoSyncCmd)
+	repoCmd.AddCommand(repoSnapshotCmd)
+}
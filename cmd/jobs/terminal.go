@@ -0,0 +1,125 @@
+package jobs
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gorilla/websocket"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/rediacc/cli/internal/api"
+	"github.com/rediacc/cli/internal/cliutil"
+)
+
+// terminalCmd opens an interactive PTY session on a machine
+var terminalCmd = &cobra.Command{
+	Use:   "terminal <machine>",
+	Short: "Open an interactive terminal on a machine",
+	Long:  "Open an interactive PTY session on a machine, over a WebSocket upgrade of the OpenTerminalSession stored procedure",
+	Args:  cobra.ExactArgs(1),
+	Run:   runTerminal,
+}
+
+func runTerminal(cmd *cobra.Command, args []string) {
+	machine := args[0]
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	response, err := client.ExecuteStoredProcedure("OpenTerminalSession", map[string]interface{}{"machine": machine})
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to open terminal session on %s: %w", machine, err))
+		return
+	}
+
+	jobID, _ := response.Outputs["job_id"].(string)
+	if jobID == "" {
+		cliutil.Fatal(cmd, fmt.Errorf("middleware did not return a terminal session id for %s", machine))
+		return
+	}
+
+	conn, err := dialTerminal(client, jobID)
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to connect terminal session on %s: %w", machine, err))
+		return
+	}
+	defer conn.Close()
+
+	cliutil.Fatal(cmd, pipeTerminal(conn))
+}
+
+// dialTerminal upgrades the middleware's /api/StoredProcedure endpoint to a
+// WebSocket for jobID, the PTY session OpenTerminalSession just started.
+func dialTerminal(client *api.Client, jobID string) (*websocket.Conn, error) {
+	wsURL := strings.Replace(client.BaseURL, "http", "ws", 1) + "/api/StoredProcedure/OpenTerminalSession/ws?jobId=" + jobID
+
+	header := http.Header{}
+	if cred := client.RequestCredential(); cred != "" {
+		header.Set("Rediacc-RequestToken", cred)
+	}
+
+	conn, _, err := websocket.DefaultDialer.Dial(wsURL, header)
+	if err != nil {
+		return nil, err
+	}
+	return conn, nil
+}
+
+// pipeTerminal puts the local terminal into raw mode and shuttles bytes
+// between stdin/stdout and conn until either side closes.
+func pipeTerminal(conn *websocket.Conn) error {
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		oldState, err := term.MakeRaw(fd)
+		if err != nil {
+			return fmt.Errorf("failed to enter raw terminal mode: %w", err)
+		}
+		defer term.Restore(fd, oldState)
+	}
+
+	done := make(chan error, 2)
+
+	go func() {
+		for {
+			_, data, err := conn.ReadMessage()
+			if err != nil {
+				done <- err
+				return
+			}
+			if _, err := os.Stdout.Write(data); err != nil {
+				done <- err
+				return
+			}
+		}
+	}()
+
+	go func() {
+		buf := make([]byte, 4096)
+		for {
+			n, err := os.Stdin.Read(buf)
+			if n > 0 {
+				if werr := conn.WriteMessage(websocket.BinaryMessage, buf[:n]); werr != nil {
+					done <- werr
+					return
+				}
+			}
+			if err != nil {
+				done <- err
+				return
+			}
+		}
+	}()
+
+	err := <-done
+	if err == io.EOF || websocket.IsCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,244 @@
+package permissions
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/rediacc/cli/internal/api"
+	"github.com/rediacc/cli/internal/bulk"
+	"github.com/rediacc/cli/internal/cliutil"
+	"github.com/rediacc/cli/internal/config"
+	"github.com/rediacc/cli/internal/format"
+)
+
+// Manifest is a declarative document listing the permission groups that
+// should exist on the server, their desired permissions, and the users
+// assigned to them. `permissions apply` diffs it against the server and
+// executes only the operations needed to match it, turning the imperative
+// add/remove/assign/groups-create subcommands into a GitOps-style reconciler.
+type Manifest struct {
+	Groups []ManifestGroup `yaml:"groups"`
+}
+
+// ManifestGroup is one permission group entry in a Manifest.
+type ManifestGroup struct {
+	Name        string   `yaml:"name"`
+	Permissions []string `yaml:"permissions"`
+	Assign      []string `yaml:"assign,omitempty"`
+}
+
+var applyCmd = &cobra.Command{
+	Use:   "apply -f <manifest.yaml>",
+	Short: "Reconcile permission groups with a declarative manifest",
+	Long:  "Read a manifest of groups/permissions/user assignments, diff it against the server, and execute only the create/delete/assign operations needed to match it; suitable for CI pipelines",
+	Run:   runApply,
+}
+
+// loadManifest reads and parses a manifest file.
+func loadManifest(path string) (*Manifest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest %s: %w", path, err)
+	}
+	var manifest Manifest
+	if err := yaml.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+	return &manifest, nil
+}
+
+// planItem is a single create/delete/assign operation computed by diffing a
+// Manifest against the server, in the dependency order runApply executes
+// them: each group's creation, its permission additions/removals, its user
+// assignments, then (with --prune) removal of groups the manifest omits.
+type planItem struct {
+	description string
+	execute     func(client *api.Client) error
+}
+
+func (p planItem) String() string { return p.description }
+
+// buildPlan diffs manifest against the server (reusing the same
+// groupPermissions/diffRole helpers `roles apply` uses, since a manifest
+// group is just a Role without inheritance) and returns the plan as an
+// ordered list of actions.
+func buildPlan(client *api.Client, manifest *Manifest, prune bool) ([]planItem, error) {
+	var plan []planItem
+	seen := map[string]bool{}
+
+	for _, g := range manifest.Groups {
+		seen[g.Name] = true
+
+		diff, err := diffRole(client, &Role{Name: g.Name, Permissions: g.Permissions})
+		if err != nil {
+			return nil, err
+		}
+
+		if !diff.GroupExists {
+			name := g.Name
+			plan = append(plan, planItem{
+				description: fmt.Sprintf("create permission group '%s'", name),
+				execute: func(client *api.Client) error {
+					return execStoredProcedure(client, "CreatePermissionGroup", map[string]interface{}{"name": name})
+				},
+			})
+		}
+
+		for _, p := range diff.ToAdd {
+			name, perm := g.Name, p
+			plan = append(plan, planItem{
+				description: fmt.Sprintf("add permission '%s' to group '%s'", perm, name),
+				execute: func(client *api.Client) error {
+					return execStoredProcedure(client, "CreatePermissionInGroup", map[string]interface{}{"group": name, "permission": perm})
+				},
+			})
+		}
+
+		for _, p := range diff.ToRemove {
+			name, perm := g.Name, p
+			plan = append(plan, planItem{
+				description: fmt.Sprintf("remove permission '%s' from group '%s'", perm, name),
+				execute: func(client *api.Client) error {
+					return execStoredProcedure(client, "DeletePermissionFromGroup", map[string]interface{}{"group": name, "permission": perm})
+				},
+			})
+		}
+
+		for _, email := range g.Assign {
+			name, user := g.Name, email
+			plan = append(plan, planItem{
+				description: fmt.Sprintf("assign '%s' to group '%s'", user, name),
+				execute: func(client *api.Client) error {
+					return execStoredProcedure(client, "UpdateUserPermissionGroup", map[string]interface{}{"userEmail": user, "group": name})
+				},
+			})
+		}
+	}
+
+	if prune {
+		pruned, err := pruneActions(client, seen)
+		if err != nil {
+			return nil, err
+		}
+		plan = append(plan, pruned...)
+	}
+
+	return plan, nil
+}
+
+// pruneActions lists the server's permission groups and returns a delete
+// action for each one not named in seen (the manifest's groups).
+func pruneActions(client *api.Client, seen map[string]bool) ([]planItem, error) {
+	response, err := client.ExecuteStoredProcedure("GetCompanyPermissionGroups", map[string]interface{}{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list permission groups for --prune: %w", err)
+	}
+
+	var plan []planItem
+	for _, row := range response.Data {
+		name, ok := row["name"].(string)
+		if !ok || seen[name] {
+			continue
+		}
+		groupName := name
+		plan = append(plan, planItem{
+			description: fmt.Sprintf("delete permission group '%s' (not in manifest)", groupName),
+			execute: func(client *api.Client) error {
+				return execStoredProcedure(client, "DeletePermissionGroup", map[string]interface{}{"name": groupName})
+			},
+		})
+	}
+	return plan, nil
+}
+
+// execStoredProcedure calls proc, discarding its Response: every planItem
+// here only needs to know whether the call succeeded, and a middleware
+// failure already comes back as an error from ExecuteStoredProcedure.
+func execStoredProcedure(client *api.Client, proc string, params map[string]interface{}) error {
+	_, err := client.ExecuteStoredProcedure(proc, params)
+	return err
+}
+
+func runApply(cmd *cobra.Command, args []string) {
+	if err := config.LoadMinimal(); err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	manifestPath, _ := cmd.Flags().GetString("file")
+	if manifestPath == "" {
+		cliutil.Fatal(cmd, fmt.Errorf("--file is required"))
+		return
+	}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	prune, _ := cmd.Flags().GetBool("prune")
+	continueOnError, _ := cmd.Flags().GetBool("continue-on-error")
+	reportPath, _ := cmd.Flags().GetString("report")
+
+	manifest, err := loadManifest(manifestPath)
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	plan, err := buildPlan(client, manifest, prune)
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	if len(plan) == 0 {
+		format.PrintSuccessTo(cmd.OutOrStdout(), "✓ Already matches manifest; nothing to do")
+		return
+	}
+
+	if dryRun {
+		rows := make([]map[string]interface{}, len(plan))
+		for i, item := range plan {
+			rows[i] = map[string]interface{}{"action": item.description}
+		}
+		cliutil.Fatal(cmd, format.Print(rows))
+		return
+	}
+
+	var results []bulk.Result
+	for _, item := range plan {
+		execErr := item.execute(client)
+		result := bulk.Result{Item: item.description, Success: execErr == nil}
+		if execErr != nil {
+			result.Error = execErr.Error()
+		}
+		results = append(results, result)
+		if execErr != nil && !continueOnError {
+			break
+		}
+	}
+
+	if reportPath != "" {
+		if err := bulk.WriteReport(reportPath, results); err != nil {
+			cliutil.Fatal(cmd, err)
+			return
+		}
+	}
+
+	cliutil.Fatal(cmd, bulk.Summarize(results))
+}
+
+func init() {
+	applyCmd.Flags().StringP("file", "f", "", "Manifest file listing groups/permissions/assignments (required)")
+	applyCmd.Flags().Bool("dry-run", false, "Print planned actions without making any changes")
+	applyCmd.Flags().Bool("prune", false, "Delete server-side permission groups not listed in the manifest")
+	applyCmd.Flags().Bool("continue-on-error", false, "Keep executing remaining actions after one fails, instead of stopping at the first, for a full summary report")
+	applyCmd.Flags().String("report", "", "Write a per-action JSONL report to this file")
+
+	PermissionsCmd.AddCommand(applyCmd)
+}
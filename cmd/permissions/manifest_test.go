@@ -0,0 +1,184 @@
+package permissions
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+
+	"github.com/rediacc/cli/internal/api"
+)
+
+// fakeServer stubs the subset of the middleware's stored-procedure API that
+// buildPlan/diffRole/pruneActions exercise: permission groups, each holding a
+// set of permission names.
+type fakeServer struct {
+	groups map[string]map[string]bool
+}
+
+func newFakeServer() *fakeServer {
+	return &fakeServer{groups: map[string]map[string]bool{}}
+}
+
+func (s *fakeServer) handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var params map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&params)
+
+		var tables []map[string]interface{}
+		outputs := map[string]interface{}{}
+		failure := 0
+
+		switch {
+		case matchProcedure(r.URL.Path, "GetPermissionGroupDetails"):
+			name, _ := params["name"].(string)
+			perms, ok := s.groups[name]
+			if !ok {
+				failure = 1
+			} else {
+				for p := range perms {
+					tables = append(tables, map[string]interface{}{"permission": p})
+				}
+			}
+		case matchProcedure(r.URL.Path, "GetCompanyPermissionGroups"):
+			for name := range s.groups {
+				tables = append(tables, map[string]interface{}{"name": name})
+			}
+		case matchProcedure(r.URL.Path, "CreatePermissionGroup"):
+			name, _ := params["name"].(string)
+			s.groups[name] = map[string]bool{}
+		case matchProcedure(r.URL.Path, "CreatePermissionInGroup"):
+			group, _ := params["group"].(string)
+			perm, _ := params["permission"].(string)
+			if s.groups[group] == nil {
+				s.groups[group] = map[string]bool{}
+			}
+			s.groups[group][perm] = true
+		case matchProcedure(r.URL.Path, "DeletePermissionFromGroup"):
+			group, _ := params["group"].(string)
+			perm, _ := params["permission"].(string)
+			delete(s.groups[group], perm)
+		case matchProcedure(r.URL.Path, "DeletePermissionGroup"):
+			name, _ := params["name"].(string)
+			delete(s.groups, name)
+		case matchProcedure(r.URL.Path, "UpdateUserPermissionGroup"):
+			// No state tracked; always succeeds.
+		default:
+			failure = 1
+		}
+
+		resp := map[string]interface{}{"failure": failure, "tables": []map[string]interface{}{{"resultSetIndex": 0, "data": tables}}, "outputs": outputs}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+	}
+}
+
+func matchProcedure(path, proc string) bool {
+	return path == "/api/StoredProcedure/"+proc
+}
+
+func (s *fakeServer) permNames(group string) []string {
+	var names []string
+	for p := range s.groups[group] {
+		names = append(names, p)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestBuildPlanCreatesMissingGroup(t *testing.T) {
+	srv := newFakeServer()
+	ts := httptest.NewServer(srv.handler())
+	defer ts.Close()
+	client := api.NewClient(ts.URL)
+
+	manifest := &Manifest{Groups: []ManifestGroup{
+		{Name: "readers", Permissions: []string{"machines.read"}},
+	}}
+
+	plan, err := buildPlan(client, manifest, false)
+	if err != nil {
+		t.Fatalf("buildPlan: %v", err)
+	}
+	if len(plan) != 2 {
+		t.Fatalf("expected create + add permission, got %d: %v", len(plan), plan)
+	}
+
+	for _, item := range plan {
+		if err := item.execute(client); err != nil {
+			t.Fatalf("execute %q: %v", item.description, err)
+		}
+	}
+	if got := srv.permNames("readers"); len(got) != 1 || got[0] != "machines.read" {
+		t.Fatalf("readers permissions = %v, want [machines.read]", got)
+	}
+}
+
+func TestBuildPlanDiffsExistingGroup(t *testing.T) {
+	srv := newFakeServer()
+	srv.groups["readers"] = map[string]bool{"machines.read": true, "teams.read": true}
+	ts := httptest.NewServer(srv.handler())
+	defer ts.Close()
+	client := api.NewClient(ts.URL)
+
+	manifest := &Manifest{Groups: []ManifestGroup{
+		{Name: "readers", Permissions: []string{"machines.read", "jobs.read"}},
+	}}
+
+	plan, err := buildPlan(client, manifest, false)
+	if err != nil {
+		t.Fatalf("buildPlan: %v", err)
+	}
+
+	var added, removed bool
+	for _, item := range plan {
+		if err := item.execute(client); err != nil {
+			t.Fatalf("execute %q: %v", item.description, err)
+		}
+		switch item.description {
+		case "add permission 'jobs.read' to group 'readers'":
+			added = true
+		case "remove permission 'teams.read' from group 'readers'":
+			removed = true
+		}
+	}
+	if !added || !removed {
+		t.Fatalf("plan did not contain expected add/remove actions: %v", plan)
+	}
+	if got := srv.permNames("readers"); len(got) != 2 || got[0] != "jobs.read" || got[1] != "machines.read" {
+		t.Fatalf("readers permissions = %v, want [jobs.read machines.read]", got)
+	}
+}
+
+func TestBuildPlanPruneDeletesUnlistedGroups(t *testing.T) {
+	srv := newFakeServer()
+	srv.groups["readers"] = map[string]bool{}
+	srv.groups["stale"] = map[string]bool{}
+	ts := httptest.NewServer(srv.handler())
+	defer ts.Close()
+	client := api.NewClient(ts.URL)
+
+	manifest := &Manifest{Groups: []ManifestGroup{{Name: "readers"}}}
+
+	plan, err := buildPlan(client, manifest, true)
+	if err != nil {
+		t.Fatalf("buildPlan: %v", err)
+	}
+
+	var pruned bool
+	for _, item := range plan {
+		if item.description == "delete permission group 'stale' (not in manifest)" {
+			pruned = true
+		}
+		if err := item.execute(client); err != nil {
+			t.Fatalf("execute %q: %v", item.description, err)
+		}
+	}
+	if !pruned {
+		t.Fatalf("expected a prune action for 'stale', got plan: %v", plan)
+	}
+	if _, ok := srv.groups["stale"]; ok {
+		t.Fatalf("'stale' group should have been deleted")
+	}
+}
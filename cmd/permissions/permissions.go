@@ -5,6 +5,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/rediacc/cli/internal/api"
+	"github.com/rediacc/cli/internal/cliutil"
 	"github.com/rediacc/cli/internal/config"
 	"github.com/rediacc/cli/internal/format"
 )
@@ -31,7 +32,7 @@ var groupsListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List permission groups",
 	Long:  "List all permission groups in the company",
-	RunE:  runGroupsList,
+	Run:   runGroupsList,
 }
 
 // groupsCreateCmd creates a permission group
@@ -40,7 +41,7 @@ var groupsCreateCmd = &cobra.Command{
 	Short: "Create permission group",
 	Long:  "Create a new permission group",
 	Args:  cobra.ExactArgs(1),
-	RunE:  runGroupsCreate,
+	Run:   runGroupsCreate,
 }
 
 // groupsDeleteCmd deletes a permission group
@@ -49,7 +50,7 @@ var groupsDeleteCmd = &cobra.Command{
 	Short: "Delete permission group",
 	Long:  "Delete an existing permission group",
 	Args:  cobra.ExactArgs(1),
-	RunE:  runGroupsDelete,
+	Run:   runGroupsDelete,
 }
 
 // groupsShowCmd shows permission group details
@@ -58,7 +59,7 @@ var groupsShowCmd = &cobra.Command{
 	Short: "Show permission group details",
 	Long:  "Display detailed information about a permission group",
 	Args:  cobra.ExactArgs(1),
-	RunE:  runGroupsShow,
+	Run:   runGroupsShow,
 }
 
 // addCmd adds a permission to a group
@@ -67,7 +68,7 @@ var addCmd = &cobra.Command{
 	Short: "Add permission to group",
 	Long:  "Add a permission to a permission group",
 	Args:  cobra.ExactArgs(2),
-	RunE:  runAdd,
+	Run:   runAdd,
 }
 
 // removeCmd removes a permission from a group
@@ -76,7 +77,7 @@ var removeCmd = &cobra.Command{
 	Short: "Remove permission from group",
 	Long:  "Remove a permission from a permission group",
 	Args:  cobra.ExactArgs(2),
-	RunE:  runRemove,
+	Run:   runRemove,
 }
 
 // assignCmd assigns a user to a permission group
@@ -85,80 +86,98 @@ var assignCmd = &cobra.Command{
 	Short: "Assign user to permission group",
 	Long:  "Assign a user to a permission group",
 	Args:  cobra.ExactArgs(2),
-	RunE:  runAssign,
+	Run:   runAssign,
 }
 
-func runGroupsList(cmd *cobra.Command, args []string) error {
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+func runGroupsList(cmd *cobra.Command, args []string) {
+	if err := config.LoadMinimal(); err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
 
-	response, err := client.ExecuteStoredProcedure("GetCompanyPermissionGroups", map[string]interface{}{})
+	client, err := api.NewAuthenticatedClient(cmd.Context())
 	if err != nil {
-		return fmt.Errorf("failed to list permission groups: %w", err)
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
 	}
 
-	if response.Success {
-		if len(response.Data) == 0 {
-			fmt.Println("No permission groups found")
-			return nil
-		}
-		return format.Print(response.Data)
+	response, err := client.ExecuteStoredProcedure("GetCompanyPermissionGroups", map[string]interface{}{})
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to list permission groups: %w", err))
+		return
 	}
 
-	return fmt.Errorf("failed to list permission groups: %s", response.Error)
+	if len(response.Data) == 0 {
+		fmt.Fprintln(cmd.OutOrStdout(), "No permission groups found")
+		return
+	}
+	cliutil.Fatal(cmd, format.Print(response.Data))
 }
 
-func runGroupsCreate(cmd *cobra.Command, args []string) error {
+func runGroupsCreate(cmd *cobra.Command, args []string) {
+	if err := config.LoadMinimal(); err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
 	name := args[0]
 
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
 
 	params := map[string]interface{}{
 		"name": name,
 	}
 
-	response, err := client.ExecuteStoredProcedure("CreatePermissionGroup", params)
+	_, err = client.ExecuteStoredProcedure("CreatePermissionGroup", params)
 	if err != nil {
-		return fmt.Errorf("failed to create permission group: %w", err)
+		cliutil.Fatal(cmd, fmt.Errorf("failed to create permission group: %w", err))
+		return
 	}
 
-	if response.Success {
-		format.PrintSuccess("✓ Permission group '%s' created successfully", name)
-		return nil
-	}
-
-	return fmt.Errorf("failed to create permission group: %s", response.Error)
+	format.PrintSuccessTo(cmd.OutOrStdout(), "✓ Permission group '%s' created successfully", name)
 }
 
-func runGroupsDelete(cmd *cobra.Command, args []string) error {
+func runGroupsDelete(cmd *cobra.Command, args []string) {
+	if err := config.LoadMinimal(); err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
 	name := args[0]
 
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
 
 	params := map[string]interface{}{
 		"name": name,
 	}
 
-	response, err := client.ExecuteStoredProcedure("DeletePermissionGroup", params)
+	_, err = client.ExecuteStoredProcedure("DeletePermissionGroup", params)
 	if err != nil {
-		return fmt.Errorf("failed to delete permission group: %w", err)
+		cliutil.Fatal(cmd, fmt.Errorf("failed to delete permission group: %w", err))
+		return
 	}
 
-	if response.Success {
-		format.PrintSuccess("✓ Permission group '%s' deleted successfully", name)
-		return nil
-	}
-
-	return fmt.Errorf("failed to delete permission group: %s", response.Error)
+	format.PrintSuccessTo(cmd.OutOrStdout(), "✓ Permission group '%s' deleted successfully", name)
 }
 
-func runGroupsShow(cmd *cobra.Command, args []string) error {
+func runGroupsShow(cmd *cobra.Command, args []string) {
+	if err := config.LoadMinimal(); err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
 	name := args[0]
 
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
 
 	params := map[string]interface{}{
 		"name": name,
@@ -166,92 +185,99 @@ func runGroupsShow(cmd *cobra.Command, args []string) error {
 
 	response, err := client.ExecuteStoredProcedure("GetPermissionGroupDetails", params)
 	if err != nil {
-		return fmt.Errorf("failed to get permission group details: %w", err)
+		cliutil.Fatal(cmd, fmt.Errorf("failed to get permission group details: %w", err))
+		return
 	}
 
-	if response.Success {
-		if len(response.Data) == 0 {
-			return fmt.Errorf("permission group '%s' not found", name)
-		}
-		return format.Print(response.Data[0])
+	if len(response.Data) == 0 {
+		cliutil.Fatal(cmd, fmt.Errorf("permission group '%s' not found", name))
+		return
 	}
-
-	return fmt.Errorf("failed to get permission group details: %s", response.Error)
+	cliutil.Fatal(cmd, format.Print(response.Data[0]))
 }
 
-func runAdd(cmd *cobra.Command, args []string) error {
+func runAdd(cmd *cobra.Command, args []string) {
+	if err := config.LoadMinimal(); err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
 	group := args[0]
 	permission := args[1]
 
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
 
 	params := map[string]interface{}{
 		"group":      group,
 		"permission": permission,
 	}
 
-	response, err := client.ExecuteStoredProcedure("CreatePermissionInGroup", params)
+	_, err = client.ExecuteStoredProcedure("CreatePermissionInGroup", params)
 	if err != nil {
-		return fmt.Errorf("failed to add permission: %w", err)
+		cliutil.Fatal(cmd, fmt.Errorf("failed to add permission: %w", err))
+		return
 	}
 
-	if response.Success {
-		format.PrintSuccess("✓ Permission '%s' added to group '%s' successfully", permission, group)
-		return nil
-	}
-
-	return fmt.Errorf("failed to add permission: %s", response.Error)
+	format.PrintSuccessTo(cmd.OutOrStdout(), "✓ Permission '%s' added to group '%s' successfully", permission, group)
 }
 
-func runRemove(cmd *cobra.Command, args []string) error {
+func runRemove(cmd *cobra.Command, args []string) {
+	if err := config.LoadMinimal(); err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
 	group := args[0]
 	permission := args[1]
 
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
 
 	params := map[string]interface{}{
 		"group":      group,
 		"permission": permission,
 	}
 
-	response, err := client.ExecuteStoredProcedure("DeletePermissionFromGroup", params)
+	_, err = client.ExecuteStoredProcedure("DeletePermissionFromGroup", params)
 	if err != nil {
-		return fmt.Errorf("failed to remove permission: %w", err)
-	}
-
-	if response.Success {
-		format.PrintSuccess("✓ Permission '%s' removed from group '%s' successfully", permission, group)
-		return nil
+		cliutil.Fatal(cmd, fmt.Errorf("failed to remove permission: %w", err))
+		return
 	}
 
-	return fmt.Errorf("failed to remove permission: %s", response.Error)
+	format.PrintSuccessTo(cmd.OutOrStdout(), "✓ Permission '%s' removed from group '%s' successfully", permission, group)
 }
 
-func runAssign(cmd *cobra.Command, args []string) error {
+func runAssign(cmd *cobra.Command, args []string) {
+	if err := config.LoadMinimal(); err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
 	userEmail := args[0]
 	group := args[1]
 
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
 
 	params := map[string]interface{}{
 		"userEmail": userEmail,
 		"group":     group,
 	}
 
-	response, err := client.ExecuteStoredProcedure("UpdateUserPermissionGroup", params)
+	_, err = client.ExecuteStoredProcedure("UpdateUserPermissionGroup", params)
 	if err != nil {
-		return fmt.Errorf("failed to assign user to group: %w", err)
-	}
-
-	if response.Success {
-		format.PrintSuccess("✓ User '%s' assigned to group '%s' successfully", userEmail, group)
-		return nil
+		cliutil.Fatal(cmd, fmt.Errorf("failed to assign user to group: %w", err))
+		return
 	}
 
-	return fmt.Errorf("failed to assign user to group: %s", response.Error)
+	format.PrintSuccessTo(cmd.OutOrStdout(), "✓ User '%s' assigned to group '%s' successfully", userEmail, group)
 }
 
 func init() {
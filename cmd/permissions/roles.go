@@ -0,0 +1,376 @@
+package permissions
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/rediacc/cli/internal/api"
+	"github.com/rediacc/cli/internal/cliutil"
+	"github.com/rediacc/cli/internal/config"
+	"github.com/rediacc/cli/internal/format"
+	"github.com/rediacc/cli/internal/utils"
+)
+
+// Role is a named, version-controlled bundle of permissions, modeled on
+// SFTPGo's roles feature. It maps onto a single permission group of the same
+// name: Permissions is the group's desired permission set, Groups is the
+// list of user emails that should be assigned to it, and Inherits names
+// sibling role files (same directory, "<name>.yaml") whose permissions are
+// merged in at load time, so a role can build on a shared base without
+// repeating it. There's no native "role" concept on the server; this is a
+// client-side convenience over permission groups and UpdateUserPermissionGroup.
+type Role struct {
+	Name        string   `yaml:"name"`
+	Permissions []string `yaml:"permissions"`
+	Groups      []string `yaml:"groups,omitempty"`
+	Inherits    []string `yaml:"inherits,omitempty"`
+}
+
+// rolesCmd groups the role-file commands under `permissions roles`.
+var rolesCmd = &cobra.Command{
+	Use:   "roles",
+	Short: "Role-based permission-set management",
+	Long:  "Define reproducible, version-controlled permission sets as role files and apply them to permission groups",
+}
+
+var rolesApplyCmd = &cobra.Command{
+	Use:   "apply <file>",
+	Short: "Apply a role file to the server",
+	Long:  "Create or update the permission group backing a role, adding and removing permissions to match the role file exactly, then assign its Groups",
+	Args:  cobra.ExactArgs(1),
+	Run:   runRolesApply,
+}
+
+var rolesDiffCmd = &cobra.Command{
+	Use:   "diff <file>",
+	Short: "Show what `roles apply` would change",
+	Long:  "Compare a role file against the server's current permission group and print the permissions that would be added or removed",
+	Args:  cobra.ExactArgs(1),
+	Run:   runRolesDiff,
+}
+
+var rolesExportCmd = &cobra.Command{
+	Use:   "export <name>",
+	Short: "Export a permission group as a role file",
+	Long:  "Print an existing permission group's permissions as a role file (to stdout; redirect to a file to check it into version control)",
+	Args:  cobra.ExactArgs(1),
+	Run:   runRolesExport,
+}
+
+var rolesAssignCmd = &cobra.Command{
+	Use:   "assign <user-email> <role>",
+	Short: "Assign a user to a role's permission group",
+	Long:  "Assign a user to the permission group backing a role",
+	Args:  cobra.ExactArgs(2),
+	Run:   runRolesAssign,
+}
+
+func init() {
+	rolesCmd.AddCommand(rolesApplyCmd)
+	rolesCmd.AddCommand(rolesDiffCmd)
+	rolesCmd.AddCommand(rolesExportCmd)
+	rolesCmd.AddCommand(rolesAssignCmd)
+	PermissionsCmd.AddCommand(rolesCmd)
+}
+
+// loadRole reads the role file at path and merges in every role it
+// (transitively) Inherits, returning the fully expanded, de-duplicated,
+// sorted permission set under the original role's Name/Groups.
+func loadRole(path string) (*Role, error) {
+	role, err := readRoleFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	perms := map[string]bool{}
+	for _, p := range role.Permissions {
+		perms[p] = true
+	}
+
+	dir := filepath.Dir(path)
+	visited := map[string]bool{path: true}
+	queue := role.Inherits
+	for len(queue) > 0 {
+		name := queue[0]
+		queue = queue[1:]
+
+		parentPath := filepath.Join(dir, name+".yaml")
+		if visited[parentPath] {
+			continue
+		}
+		visited[parentPath] = true
+
+		parent, err := readRoleFile(parentPath)
+		if err != nil {
+			return nil, fmt.Errorf("role %q inherits %q: %w", role.Name, name, err)
+		}
+		for _, p := range parent.Permissions {
+			perms[p] = true
+		}
+		queue = append(queue, parent.Inherits...)
+	}
+
+	role.Permissions = role.Permissions[:0]
+	for p := range perms {
+		role.Permissions = append(role.Permissions, p)
+	}
+	sort.Strings(role.Permissions)
+	return role, nil
+}
+
+// readRoleFile parses a single role file without resolving Inherits.
+func readRoleFile(path string) (*Role, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read role file %s: %w", path, err)
+	}
+	var role Role
+	if err := yaml.Unmarshal(data, &role); err != nil {
+		return nil, fmt.Errorf("failed to parse role file %s: %w", path, err)
+	}
+	if role.Name == "" {
+		return nil, fmt.Errorf("role file %s is missing a name", path)
+	}
+	return &role, nil
+}
+
+// groupPermissions fetches a permission group's current permission names. A
+// group that doesn't exist yet is reported as an empty set, not an error, so
+// diffRole can treat "create" and "update" as the same add-only diff: the
+// middleware reports a missing group as a failure response (decoded by
+// ExecuteStoredProcedure into a *utils.APIError), not a transport-level
+// error, so only that kind of failure is swallowed here.
+func groupPermissions(client *api.Client, name string) (map[string]bool, error) {
+	response, err := client.ExecuteStoredProcedure("GetPermissionGroupDetails", map[string]interface{}{"name": name})
+	if err != nil {
+		if _, ok := err.(*utils.APIError); ok {
+			return map[string]bool{}, nil
+		}
+		return nil, fmt.Errorf("failed to get permission group '%s': %w", name, err)
+	}
+
+	perms := map[string]bool{}
+	for _, row := range response.Data {
+		if p, ok := row["permission"].(string); ok {
+			perms[p] = true
+		}
+	}
+	return perms, nil
+}
+
+// roleDiff is the set of permission additions/removals `roles apply` would
+// make to reconcile the server with a role file.
+type roleDiff struct {
+	GroupExists bool
+	ToAdd       []string
+	ToRemove    []string
+}
+
+func diffRole(client *api.Client, role *Role) (*roleDiff, error) {
+	current, err := groupPermissions(client, role.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	desired := map[string]bool{}
+	for _, p := range role.Permissions {
+		desired[p] = true
+	}
+
+	diff := &roleDiff{GroupExists: len(current) > 0}
+	for p := range desired {
+		if !current[p] {
+			diff.ToAdd = append(diff.ToAdd, p)
+		}
+	}
+	for p := range current {
+		if !desired[p] {
+			diff.ToRemove = append(diff.ToRemove, p)
+		}
+	}
+	sort.Strings(diff.ToAdd)
+	sort.Strings(diff.ToRemove)
+	return diff, nil
+}
+
+func runRolesDiff(cmd *cobra.Command, args []string) {
+	if err := config.LoadMinimal(); err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+	role, err := loadRole(args[0])
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	diff, err := diffRole(client, role)
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	out := cmd.OutOrStdout()
+	if !diff.GroupExists {
+		fmt.Fprintf(out, "permission group '%s' does not exist yet; would be created with %d permission(s)\n", role.Name, len(role.Permissions))
+	}
+	for _, p := range diff.ToAdd {
+		fmt.Fprintf(out, "+ %s\n", p)
+	}
+	for _, p := range diff.ToRemove {
+		fmt.Fprintf(out, "- %s\n", p)
+	}
+	if len(diff.ToAdd) == 0 && len(diff.ToRemove) == 0 {
+		fmt.Fprintf(out, "permission group '%s' already matches role '%s'\n", role.Name, role.Name)
+	}
+}
+
+// runRolesApply reconciles the permission group backing role.Name with
+// role.Permissions and assigns role.Groups to it. If any step fails
+// partway through, it rolls back everything it had already done (deleting
+// permissions it added, restoring permissions it removed, and deleting the
+// group itself if this apply is the one that created it) before returning
+// the error, so a failed apply never leaves the group in a half-updated
+// state.
+func runRolesApply(cmd *cobra.Command, args []string) {
+	if err := config.LoadMinimal(); err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+	role, err := loadRole(args[0])
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	diff, err := diffRole(client, role)
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	created := !diff.GroupExists
+	if created {
+		_, err := client.ExecuteStoredProcedure("CreatePermissionGroup", map[string]interface{}{"name": role.Name})
+		if err != nil {
+			cliutil.Fatal(cmd, fmt.Errorf("failed to create permission group '%s': %w", role.Name, err))
+			return
+		}
+	}
+
+	var added, removed []string
+	rollback := func() {
+		for _, p := range removed {
+			_, _ = client.ExecuteStoredProcedure("CreatePermissionInGroup", map[string]interface{}{"group": role.Name, "permission": p})
+		}
+		for _, p := range added {
+			_, _ = client.ExecuteStoredProcedure("DeletePermissionFromGroup", map[string]interface{}{"group": role.Name, "permission": p})
+		}
+		if created {
+			_, _ = client.ExecuteStoredProcedure("DeletePermissionGroup", map[string]interface{}{"name": role.Name})
+		}
+	}
+
+	for _, p := range diff.ToAdd {
+		_, err := client.ExecuteStoredProcedure("CreatePermissionInGroup", map[string]interface{}{"group": role.Name, "permission": p})
+		if err != nil {
+			rollback()
+			cliutil.Fatal(cmd, fmt.Errorf("failed to add permission '%s' to '%s': %w", p, role.Name, err))
+			return
+		}
+		added = append(added, p)
+	}
+
+	for _, p := range diff.ToRemove {
+		_, err := client.ExecuteStoredProcedure("DeletePermissionFromGroup", map[string]interface{}{"group": role.Name, "permission": p})
+		if err != nil {
+			rollback()
+			cliutil.Fatal(cmd, fmt.Errorf("failed to remove permission '%s' from '%s': %w", p, role.Name, err))
+			return
+		}
+		removed = append(removed, p)
+	}
+
+	for _, email := range role.Groups {
+		_, err := client.ExecuteStoredProcedure("UpdateUserPermissionGroup", map[string]interface{}{"userEmail": email, "group": role.Name})
+		if err != nil {
+			cliutil.Fatal(cmd, fmt.Errorf("applied '%s' permissions but failed to assign '%s': %w", role.Name, email, err))
+			return
+		}
+	}
+
+	format.PrintSuccessTo(cmd.OutOrStdout(), "✓ Role '%s' applied: %d added, %d removed, %d user(s) assigned", role.Name, len(diff.ToAdd), len(diff.ToRemove), len(role.Groups))
+}
+
+func runRolesExport(cmd *cobra.Command, args []string) {
+	if err := config.LoadMinimal(); err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+	name := args[0]
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	perms, err := groupPermissions(client, name)
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	role := Role{Name: name}
+	for p := range perms {
+		role.Permissions = append(role.Permissions, p)
+	}
+	sort.Strings(role.Permissions)
+
+	data, err := yaml.Marshal(role)
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to encode role '%s': %w", name, err))
+		return
+	}
+	_, err = cmd.OutOrStdout().Write(data)
+	cliutil.Fatal(cmd, err)
+}
+
+func runRolesAssign(cmd *cobra.Command, args []string) {
+	if err := config.LoadMinimal(); err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+	userEmail, role := args[0], args[1]
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	_, err = client.ExecuteStoredProcedure("UpdateUserPermissionGroup", map[string]interface{}{"userEmail": userEmail, "group": role})
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to assign '%s' to role '%s': %w", userEmail, role, err))
+		return
+	}
+
+	format.PrintSuccessTo(cmd.OutOrStdout(), "✓ User '%s' assigned to role '%s'", userEmail, role)
+}
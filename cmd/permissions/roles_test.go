@@ -0,0 +1,99 @@
+package permissions
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/rediacc/cli/internal/api"
+)
+
+func writeRoleFile(t *testing.T, dir, name, contents string) string {
+	t.Helper()
+	path := filepath.Join(dir, name+".yaml")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+	return path
+}
+
+func TestLoadRoleMergesInherits(t *testing.T) {
+	dir := t.TempDir()
+	writeRoleFile(t, dir, "base", "name: base\npermissions:\n  - machines.read\n")
+	path := writeRoleFile(t, dir, "admin", "name: admin\ninherits:\n  - base\npermissions:\n  - machines.write\n")
+
+	role, err := loadRole(path)
+	if err != nil {
+		t.Fatalf("loadRole: %v", err)
+	}
+	if role.Name != "admin" {
+		t.Fatalf("Name = %q, want admin", role.Name)
+	}
+	want := []string{"machines.read", "machines.write"}
+	if len(role.Permissions) != len(want) {
+		t.Fatalf("Permissions = %v, want %v", role.Permissions, want)
+	}
+	for i, p := range want {
+		if role.Permissions[i] != p {
+			t.Fatalf("Permissions = %v, want %v", role.Permissions, want)
+		}
+	}
+}
+
+func TestLoadRoleIgnoresInheritCycle(t *testing.T) {
+	dir := t.TempDir()
+	writeRoleFile(t, dir, "a", "name: a\ninherits:\n  - b\npermissions:\n  - a.perm\n")
+	path := writeRoleFile(t, dir, "b", "name: b\ninherits:\n  - a\npermissions:\n  - b.perm\n")
+
+	role, err := loadRole(path)
+	if err != nil {
+		t.Fatalf("loadRole: %v", err)
+	}
+	if len(role.Permissions) != 2 {
+		t.Fatalf("Permissions = %v, want 2 entries (a.perm, b.perm)", role.Permissions)
+	}
+}
+
+func TestDiffRoleAddsAndRemoves(t *testing.T) {
+	srv := newFakeServer()
+	srv.groups["reviewers"] = map[string]bool{"jobs.read": true}
+	ts := httptest.NewServer(srv.handler())
+	defer ts.Close()
+	client := api.NewClient(ts.URL)
+
+	diff, err := diffRole(client, &Role{Name: "reviewers", Permissions: []string{"jobs.read", "machines.read"}})
+	if err != nil {
+		t.Fatalf("diffRole: %v", err)
+	}
+	if !diff.GroupExists {
+		t.Fatalf("GroupExists = false, want true")
+	}
+	if len(diff.ToAdd) != 1 || diff.ToAdd[0] != "machines.read" {
+		t.Fatalf("ToAdd = %v, want [machines.read]", diff.ToAdd)
+	}
+	if len(diff.ToRemove) != 0 {
+		t.Fatalf("ToRemove = %v, want none", diff.ToRemove)
+	}
+}
+
+func TestDiffRoleMissingGroupIsAddOnly(t *testing.T) {
+	srv := newFakeServer()
+	ts := httptest.NewServer(srv.handler())
+	defer ts.Close()
+	client := api.NewClient(ts.URL)
+
+	diff, err := diffRole(client, &Role{Name: "new-group", Permissions: []string{"jobs.read"}})
+	if err != nil {
+		t.Fatalf("diffRole: %v", err)
+	}
+	if diff.GroupExists {
+		t.Fatalf("GroupExists = true, want false for a group the server doesn't have")
+	}
+	if len(diff.ToAdd) != 1 || diff.ToAdd[0] != "jobs.read" {
+		t.Fatalf("ToAdd = %v, want [jobs.read]", diff.ToAdd)
+	}
+	if len(diff.ToRemove) != 0 {
+		t.Fatalf("ToRemove = %v, want none", diff.ToRemove)
+	}
+}
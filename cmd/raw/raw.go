@@ -1,7 +1,19 @@
 package raw
 
 import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
 	"github.com/spf13/cobra"
+	"github.com/rediacc/cli/internal/api"
+	"github.com/rediacc/cli/internal/cliutil"
+	"github.com/rediacc/cli/internal/config"
+	"github.com/rediacc/cli/internal/format"
+	"github.com/rediacc/cli/internal/prompt"
 )
 
 // RawCmd represents the raw command
@@ -9,11 +21,219 @@ var RawCmd = &cobra.Command{
 	Use:   "raw",
 	Short: "Raw stored procedure execution commands",
 	Long: `Raw stored procedure execution commands for Rediacc CLI.
-	
+
 This command group allows direct execution of stored procedures
 and listing available procedures.`,
 }
 
+// listCmd enumerates stored procedures known to the middleware
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List available stored procedures",
+	Long:  "List stored procedures exposed by the middleware, optionally narrowed with --filter",
+	Run:   runList,
+}
+
+// describeCmd shows the parameters expected by a stored procedure
+var describeCmd = &cobra.Command{
+	Use:   "describe <proc>",
+	Short: "Describe a stored procedure",
+	Long:  "Show the parameters and types expected by a stored procedure",
+	Args:  cobra.ExactArgs(1),
+	Run:   runDescribe,
+}
+
+// execCmd invokes an arbitrary stored procedure
+var execCmd = &cobra.Command{
+	Use:   "exec <proc>",
+	Short: "Execute a stored procedure",
+	Long:  "Invoke a stored procedure directly with the given parameters",
+	Args:  cobra.ExactArgs(1),
+	Run:   runExec,
+}
+
+// parseParams merges repeated --param key=value and --param-json key=@file.json
+// flags into a single parameter map for ExecuteStoredProcedure.
+func parseParams(params, paramsJSON []string) (map[string]interface{}, error) {
+	result := make(map[string]interface{}, len(params)+len(paramsJSON))
+
+	for _, kv := range params {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --param %q, expected key=value", kv)
+		}
+		result[key] = value
+	}
+
+	for _, kv := range paramsJSON {
+		key, value, ok := strings.Cut(kv, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid --param-json %q, expected key=value or key=@file.json", kv)
+		}
+
+		raw := value
+		if strings.HasPrefix(value, "@") {
+			data, err := os.ReadFile(value[1:])
+			if err != nil {
+				return nil, fmt.Errorf("failed to read %s: %w", value[1:], err)
+			}
+			raw = string(data)
+		}
+
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(raw), &decoded); err != nil {
+			return nil, fmt.Errorf("invalid JSON for --param-json %s: %w", key, err)
+		}
+		result[key] = decoded
+	}
+
+	return result, nil
+}
+
+func runList(cmd *cobra.Command, args []string) {
+	filter, _ := cmd.Flags().GetString("filter")
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	response, err := client.ExecuteStoredProcedure("ListStoredProcedures", map[string]interface{}{})
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to list stored procedures: %w", err))
+		return
+	}
+
+	data := response.Data
+	if filter != "" {
+		filtered := make([]map[string]interface{}, 0, len(data))
+		for _, row := range data {
+			name, _ := row["name"].(string)
+			if matched, _ := filepath.Match(filter, name); matched {
+				filtered = append(filtered, row)
+			}
+		}
+		data = filtered
+	}
+
+	if len(data) == 0 {
+		fmt.Println("No stored procedures found")
+		return
+	}
+	cliutil.Fatal(cmd, format.Print(data))
+}
+
+func runDescribe(cmd *cobra.Command, args []string) {
+	proc := args[0]
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	response, err := client.ExecuteStoredProcedure("DescribeStoredProcedure", map[string]interface{}{
+		"procedure": proc,
+	})
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to describe %s: %w", proc, err))
+		return
+	}
+	if len(response.Data) == 0 {
+		cliutil.Fatal(cmd, fmt.Errorf("no parameter information available for %s", proc))
+		return
+	}
+	cliutil.Fatal(cmd, format.Print(response.Data))
+}
+
+func runExec(cmd *cobra.Command, args []string) {
+	proc := args[0]
+
+	paramFlags, _ := cmd.Flags().GetStringArray("param")
+	paramJSONFlags, _ := cmd.Flags().GetStringArray("param-json")
+	useAuth, _ := cmd.Flags().GetBool("auth")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+	repeat, _ := cmd.Flags().GetInt("repeat")
+
+	params, err := parseParams(paramFlags, paramJSONFlags)
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+	if timeout > 0 {
+		client.HTTPClient.Timeout = timeout
+	}
+
+	if repeat < 1 {
+		repeat = 1
+	}
+
+	var email, password string
+	if useAuth {
+		email = config.Get().Auth.Email
+		password, err = prompt.Password(fmt.Sprintf("Enter password for %s to execute %s: ", email, proc))
+		if err != nil {
+			cliutil.Fatal(cmd, err)
+			return
+		}
+	}
+
+	var lastErr error
+	for i := 0; i < repeat; i++ {
+		start := time.Now()
+
+		var response *api.Response
+		if useAuth {
+			_, err = client.ExecuteAuthProcedure(proc, params, email, password)
+			lastErr = err
+		} else {
+			response, err = client.ExecuteStoredProcedure(proc, params)
+			lastErr = err
+		}
+
+		elapsed := time.Since(start)
+		if repeat > 1 {
+			status := "ok"
+			if lastErr != nil {
+				status = "error: " + lastErr.Error()
+			}
+			fmt.Printf("[%d/%d] %s in %s\n", i+1, repeat, status, elapsed)
+			continue
+		}
+
+		if lastErr != nil {
+			cliutil.Fatal(cmd, fmt.Errorf("failed to execute %s: %w", proc, lastErr))
+			return
+		}
+		if response != nil {
+			cliutil.Fatal(cmd, format.Print(response.Data))
+			return
+		}
+		format.PrintSuccess("✓ %s executed successfully", proc)
+	}
+
+	if lastErr != nil && repeat > 1 {
+		cliutil.Fatal(cmd, fmt.Errorf("%s failed on at least one of %d attempts: %w", proc, repeat, lastErr))
+	}
+}
+
 func init() {
-	// TODO: Add subcommands
+	listCmd.Flags().String("filter", "", "Glob pattern to filter procedure names, e.g. 'Get*'")
+
+	execCmd.Flags().StringArray("param", nil, "Stored procedure parameter as key=value (repeatable)")
+	execCmd.Flags().StringArray("param-json", nil, "Stored procedure parameter as key=<json> or key=@file.json (repeatable)")
+	execCmd.Flags().Bool("auth", false, "Route through ExecuteAuthProcedure, prompting for the current user's password")
+	execCmd.Flags().Duration("timeout", 0, "Override the HTTP client timeout for this call")
+	execCmd.Flags().Int("repeat", 1, "Repeat the call N times, useful for load-testing")
+
+	RawCmd.AddCommand(listCmd)
+	RawCmd.AddCommand(describeCmd)
+	RawCmd.AddCommand(execCmd)
 }
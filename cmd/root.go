@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -18,13 +19,26 @@ import (
 	"github.com/rediacc/cli/cmd/schedules"
 	"github.com/rediacc/cli/cmd/storage"
 	"github.com/rediacc/cli/cmd/teams"
+	"github.com/rediacc/cli/cmd/tui"
 	appConfig "github.com/rediacc/cli/internal/config"
 )
 
 var (
-	cfgFile string
-	debug   bool
-	output  string
+	cfgFile   string
+	debug     bool
+	verbose   bool
+	output    string
+	noHeaders bool
+	fields    string
+	columns   string
+	query     string
+	jq        string
+	profile   string
+
+	template     string
+	templateFile string
+
+	credentialsBackend string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -37,7 +51,17 @@ available through the Rediacc middleware service.
 The CLI communicates with the Rediacc middleware via HTTP/REST API,
 which in turn interfaces with the SQL Server database through stored procedures.`,
 	Version: "1.0.0",
+	// Runtime failures are reported by the failing command itself (commands
+	// with business logic use Run + cliutil.Fatal, not RunE), so cobra's
+	// default "print error + dump usage" behavior would just be noise.
+	SilenceUsage:  true,
+	SilenceErrors: true,
 	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		// The active profile affects which Server/Auth/... values
+		// Initialize loads, so it must be recorded before calling it.
+		appConfig.SetProfileOverride(profile)
+		appConfig.SetCredentialsBackendOverride(credentialsBackend)
+
 		// Initialize configuration
 		if err := appConfig.Initialize(cfgFile); err != nil {
 			return fmt.Errorf("failed to initialize configuration: %w", err)
@@ -48,11 +72,48 @@ which in turn interfaces with the SQL Server database through stored procedures.
 			appConfig.SetDebug(true)
 		}
 
+		// Set verbose request/response logging
+		if verbose {
+			appConfig.SetVerbose(true)
+		}
+
 		// Set output format
 		if output != "" {
 			appConfig.SetOutputFormat(output)
 		}
 
+		// --template/--template-file select template output without having
+		// to cram the template text into `-o template=...`; --template-file
+		// wins if both are given. Either one implies `-o template=...`.
+		templateText := template
+		if templateFile != "" {
+			data, err := os.ReadFile(templateFile)
+			if err != nil {
+				return fmt.Errorf("failed to read --template-file: %w", err)
+			}
+			templateText = string(data)
+		}
+		if templateText != "" {
+			appConfig.SetOutputFormat("template=" + templateText)
+		}
+
+		// Set tabular formatting options
+		appConfig.SetNoHeaders(noHeaders)
+		if fields != "" {
+			appConfig.SetFields(strings.Split(fields, ","))
+		}
+		if columns != "" {
+			appConfig.SetColumns(strings.Split(columns, ","))
+		}
+
+		// --jq is an alias for --query; --query wins if both are given.
+		switch {
+		case query != "":
+			appConfig.SetQuery(query)
+		case jq != "":
+			appConfig.SetQuery(jq)
+		}
+
 		return nil
 	},
 }
@@ -69,7 +130,17 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.rediacc-cli.yaml)")
 	rootCmd.PersistentFlags().BoolVar(&debug, "debug", false, "enable debug mode")
-	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", "table", "output format (table, json, yaml, text)")
+	rootCmd.PersistentFlags().BoolVar(&verbose, "verbose", false, "log every request and response the API client makes to stderr")
+	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", "table", "output format (table, json, yaml, text, csv, tsv, markdown, html, template=..., jsonpath=...)")
+	rootCmd.PersistentFlags().BoolVar(&noHeaders, "no-headers", false, "omit header rows from tabular output, for scripting")
+	rootCmd.PersistentFlags().StringVar(&fields, "fields", "", "comma-separated list of fields to project, e.g. email,status,last_login_at")
+	rootCmd.PersistentFlags().StringVar(&columns, "columns", "", "comma-separated, ordered column list for table/csv/tsv/markdown/html output, e.g. email,role,created_at")
+	rootCmd.PersistentFlags().StringVar(&query, "query", "", "jq program applied to the response before formatting, e.g. '.[] | select(.active) | .email'; also accepts the older dotted-path syntax, e.g. users.0.email")
+	rootCmd.PersistentFlags().StringVar(&jq, "jq", "", "alias for --query")
+	rootCmd.PersistentFlags().StringVar(&template, "template", "", "Go text/template applied to the response, e.g. '{{range .}}{{.name}}\\n{{end}}'; shorthand for -o template=...")
+	rootCmd.PersistentFlags().StringVar(&templateFile, "template-file", "", "path to a Go text/template file; overrides --template")
+	rootCmd.PersistentFlags().StringVar(&profile, "profile", "", "named config profile to use for this invocation (see `rediacc config profile list`), overrides REDIACC_PROFILE and `config use`")
+	rootCmd.PersistentFlags().StringVar(&credentialsBackend, "credentials-backend", "", "backend that stores the session credential: keyring (default), file, or env (for CI); overrides the `credentials.backend` config key")
 
 	// Add subcommands
 	rootCmd.AddCommand(auth.AuthCmd)
@@ -83,6 +154,7 @@ func init() {
 	rootCmd.AddCommand(jobs.JobsCmd)
 	rootCmd.AddCommand(config.ConfigCmd)
 	rootCmd.AddCommand(raw.RawCmd)
+	rootCmd.AddCommand(tui.TuiCmd)
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -101,8 +173,9 @@ func initConfig() {
 		viper.SetConfigName(".rediacc-cli")
 	}
 
-	// Environment variables
+	// Environment variables, e.g. REDIACC_SERVER_URL for server.url
 	viper.SetEnvPrefix("REDIACC")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
 	viper.AutomaticEnv()
 
 	// If a config file is found, read it in.
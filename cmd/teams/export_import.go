@@ -0,0 +1,218 @@
+package teams
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/rediacc/cli/internal/api"
+	"github.com/rediacc/cli/internal/bulk"
+	"github.com/rediacc/cli/internal/cliutil"
+	"github.com/rediacc/cli/internal/format"
+)
+
+// exportCmd dumps teams and their memberships as JSONL
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export teams and memberships as JSONL",
+	Long:  "Export all teams and team memberships as newline-delimited JSON, for backup or migration",
+	Run:   runExport,
+}
+
+// importCmd loads teams and memberships from JSONL
+var importCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import teams and memberships from JSONL",
+	Long:  "Import teams and team memberships from a newline-delimited JSON file produced by `teams export`",
+	Args:  cobra.ExactArgs(1),
+	Run:   runImport,
+}
+
+func runExport(cmd *cobra.Command, args []string) {
+	outputFile, _ := cmd.Flags().GetString("file")
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	teamsResp, err := client.ExecuteStoredProcedure("GetCompanyTeams", map[string]interface{}{})
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to list teams: %w", err))
+		return
+	}
+
+	var records []map[string]interface{}
+	for _, team := range teamsResp.Data {
+		name, _ := team["name"].(string)
+
+		records = append(records, map[string]interface{}{
+			"type": "team",
+			"name": name,
+		})
+
+		membersResp, err := client.ExecuteStoredProcedure("GetTeamMembers", map[string]interface{}{
+			"name": name,
+		})
+		if err != nil {
+			cliutil.Fatal(cmd, fmt.Errorf("failed to list members of team '%s': %w", name, err))
+			return
+		}
+
+		for _, member := range membersResp.Data {
+			email, _ := member["email"].(string)
+			records = append(records, map[string]interface{}{
+				"type":  "membership",
+				"team":  name,
+				"email": email,
+			})
+		}
+	}
+
+	out := os.Stdout
+	if outputFile != "" && outputFile != "-" {
+		f, err := os.Create(outputFile)
+		if err != nil {
+			cliutil.Fatal(cmd, fmt.Errorf("failed to create %s: %w", outputFile, err))
+			return
+		}
+		defer f.Close()
+		out = f
+	}
+
+	cliutil.Fatal(cmd, bulk.WriteJSONL(bufio.NewWriter(out), records))
+}
+
+func runImport(cmd *cobra.Command, args []string) {
+	path := args[0]
+
+	onConflictFlag, _ := cmd.Flags().GetString("on-conflict")
+	onConflict, err := bulk.ParseOnConflict(onConflictFlag)
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	parallelism, _ := cmd.Flags().GetInt("parallelism")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+	reportPath, _ := cmd.Flags().GetString("report")
+
+	records, err := bulk.ReadJSONL(path)
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
+
+	existingTeams, err := loadExistingTeamNames(client)
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	// Teams must exist before memberships reference them, so they are
+	// imported sequentially and ahead of the (parallelizable) membership pass.
+	var teamRecords, membershipRecords []map[string]interface{}
+	for _, record := range records {
+		switch record["type"] {
+		case "team":
+			teamRecords = append(teamRecords, record)
+		case "membership":
+			membershipRecords = append(membershipRecords, record)
+		default:
+			cliutil.Fatal(cmd, fmt.Errorf("unknown record type %q", record["type"]))
+			return
+		}
+	}
+
+	for _, record := range teamRecords {
+		name, _ := record["name"].(string)
+		if existingTeams[name] {
+			switch onConflict {
+			case bulk.OnConflictSkip:
+				format.PrintInfo("skipping existing team '%s'", name)
+				continue
+			case bulk.OnConflictFail:
+				cliutil.Fatal(cmd, fmt.Errorf("team '%s' already exists", name))
+				return
+			case bulk.OnConflictUpdate:
+				format.PrintInfo("team '%s' already exists (no updatable fields, treating as skip)", name)
+				continue
+			}
+		}
+
+		if dryRun {
+			format.PrintInfo("(dry-run) would create team '%s'", name)
+			continue
+		}
+
+		_, err := client.ExecuteStoredProcedure("CreateTeam", map[string]interface{}{
+			"teamName":  name,
+			"teamVault": "{}",
+		})
+		if err != nil {
+			cliutil.Fatal(cmd, fmt.Errorf("failed to create team '%s': %w", name, err))
+			return
+		}
+		format.PrintSuccess("✓ Team '%s' created", name)
+	}
+
+	results := bulk.RunPool(membershipRecords, parallelism, func(record map[string]interface{}) error {
+		team, _ := record["team"].(string)
+		email, _ := record["email"].(string)
+
+		if dryRun {
+			format.PrintInfo("(dry-run) would add '%s' to team '%s'", email, team)
+			return nil
+		}
+
+		_, err := client.ExecuteStoredProcedure("AddUserToTeam", map[string]interface{}{
+			"Team":         team,
+			"NewUserEmail": email,
+		})
+		return err
+	})
+
+	if reportPath != "" {
+		if err := bulk.WriteReport(reportPath, results); err != nil {
+			cliutil.Fatal(cmd, err)
+			return
+		}
+	}
+
+	cliutil.Fatal(cmd, bulk.Summarize(results))
+}
+
+func loadExistingTeamNames(client *api.Client) (map[string]bool, error) {
+	response, err := client.ExecuteStoredProcedure("GetCompanyTeams", map[string]interface{}{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing teams: %w", err)
+	}
+
+	names := make(map[string]bool, len(response.Data))
+	for _, team := range response.Data {
+		if name, ok := team["name"].(string); ok {
+			names[name] = true
+		}
+	}
+	return names, nil
+}
+
+func init() {
+	exportCmd.Flags().StringP("file", "f", "-", "Output file (defaults to stdout)")
+
+	importCmd.Flags().String("on-conflict", string(bulk.OnConflictFail), "How to handle records that already exist: skip, update, or fail")
+	importCmd.Flags().Int("parallelism", 1, "Number of concurrent membership import workers")
+	importCmd.Flags().Bool("dry-run", false, "Print what would happen without making any changes")
+	importCmd.Flags().String("report", "", "Write a per-record JSONL report to this file")
+
+	TeamsCmd.AddCommand(exportCmd)
+	TeamsCmd.AddCommand(importCmd)
+}
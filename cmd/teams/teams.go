@@ -5,10 +5,40 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/rediacc/cli/internal/api"
-	"github.com/rediacc/cli/internal/config"
+	"github.com/rediacc/cli/internal/cliutil"
+	"github.com/rediacc/cli/internal/completion"
 	"github.com/rediacc/cli/internal/format"
 )
 
+// completeTeamNames offers cached team names for the first positional argument.
+func completeTeamNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completion.Teams(cmd.Context(), completion.DefaultTTL), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeUserEmails offers cached user emails for the second positional argument.
+func completeUserEmails(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) != 1 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completion.UserEmails(cmd.Context(), completion.DefaultTTL), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeTeamThenEmail completes a team name in the first position and a
+// user email in the second, for the <team-name> <user-email> commands.
+func completeTeamThenEmail(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	switch len(args) {
+	case 0:
+		return completion.Teams(cmd.Context(), completion.DefaultTTL), cobra.ShellCompDirectiveNoFileComp
+	case 1:
+		return completion.UserEmails(cmd.Context(), completion.DefaultTTL), cobra.ShellCompDirectiveNoFileComp
+	default:
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
 // TeamsCmd represents the teams command
 var TeamsCmd = &cobra.Command{
 	Use:   "teams",
@@ -24,7 +54,7 @@ var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List teams",
 	Long:  "List all teams in the company",
-	RunE:  runList,
+	Run:   runList,
 }
 
 // createCmd creates a new team
@@ -33,25 +63,27 @@ var createCmd = &cobra.Command{
 	Short: "Create a new team",
 	Long:  "Create a new team with the specified name",
 	Args:  cobra.ExactArgs(1),
-	RunE:  runCreate,
+	Run:   runCreate,
 }
 
 // deleteCmd deletes a team
 var deleteCmd = &cobra.Command{
-	Use:   "delete <name>",
-	Short: "Delete a team",
-	Long:  "Delete an existing team",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runDelete,
+	Use:               "delete <name>",
+	Short:             "Delete a team",
+	Long:              "Delete an existing team",
+	Args:              cobra.ExactArgs(1),
+	Run:               runDelete,
+	ValidArgsFunction: completeTeamNames,
 }
 
 // renameCmd renames a team
 var renameCmd = &cobra.Command{
-	Use:   "rename <old-name> <new-name>",
-	Short: "Rename a team",
-	Long:  "Rename an existing team",
-	Args:  cobra.ExactArgs(2),
-	RunE:  runRename,
+	Use:               "rename <old-name> <new-name>",
+	Short:             "Rename a team",
+	Long:              "Rename an existing team",
+	Args:              cobra.ExactArgs(2),
+	Run:               runRename,
+	ValidArgsFunction: completeTeamNames,
 }
 
 // membersCmd manages team members
@@ -63,56 +95,62 @@ var membersCmd = &cobra.Command{
 
 // membersListCmd lists team members
 var membersListCmd = &cobra.Command{
-	Use:   "list <team-name>",
-	Short: "List team members",
-	Long:  "List all members of a specific team",
-	Args:  cobra.ExactArgs(1),
-	RunE:  runMembersList,
+	Use:               "list <team-name>",
+	Short:             "List team members",
+	Long:              "List all members of a specific team",
+	Args:              cobra.ExactArgs(1),
+	Run:               runMembersList,
+	ValidArgsFunction: completeTeamNames,
 }
 
 // membersAddCmd adds a member to a team
 var membersAddCmd = &cobra.Command{
-	Use:   "add <team-name> <user-email>",
-	Short: "Add team member",
-	Long:  "Add a user to a team",
-	Args:  cobra.ExactArgs(2),
-	RunE:  runMembersAdd,
+	Use:               "add <team-name> <user-email>",
+	Short:             "Add team member",
+	Long:              "Add a user to a team",
+	Args:              cobra.ExactArgs(2),
+	Run:               runMembersAdd,
+	ValidArgsFunction: completeTeamThenEmail,
 }
 
 // membersRemoveCmd removes a member from a team
 var membersRemoveCmd = &cobra.Command{
-	Use:   "remove <team-name> <user-email>",
-	Short: "Remove team member",
-	Long:  "Remove a user from a team",
-	Args:  cobra.ExactArgs(2),
-	RunE:  runMembersRemove,
+	Use:               "remove <team-name> <user-email>",
+	Short:             "Remove team member",
+	Long:              "Remove a user from a team",
+	Args:              cobra.ExactArgs(2),
+	Run:               runMembersRemove,
+	ValidArgsFunction: completeTeamThenEmail,
 }
 
-func runList(cmd *cobra.Command, args []string) error {
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+func runList(cmd *cobra.Command, args []string) {
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
 
 	response, err := client.ExecuteStoredProcedure("GetCompanyTeams", map[string]interface{}{})
 	if err != nil {
-		return fmt.Errorf("failed to list teams: %w", err)
+		cliutil.Fatal(cmd, fmt.Errorf("failed to list teams: %w", err))
+		return
 	}
 
-	if response.Success {
-		if len(response.Data) == 0 {
-			fmt.Println("No teams found")
-			return nil
-		}
-		return format.Print(response.Data)
+	if len(response.Data) == 0 {
+		fmt.Println("No teams found")
+		return
 	}
-
-	return fmt.Errorf("failed to list teams: %s", response.Error)
+	cliutil.Fatal(cmd, format.Print(response.Data))
 }
 
-func runCreate(cmd *cobra.Command, args []string) error {
+func runCreate(cmd *cobra.Command, args []string) {
 	name := args[0]
 
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
 
 	// CreateTeam expects teamName and teamVault parameters (from tutorial)
 	params := map[string]interface{}{
@@ -122,70 +160,68 @@ func runCreate(cmd *cobra.Command, args []string) error {
 
 	response, err := client.ExecuteStoredProcedure("CreateTeam", params)
 	if err != nil {
-		return fmt.Errorf("failed to create team: %w", err)
-	}
-
-	if response.Success {
-		format.PrintSuccess("✓ Team '%s' created successfully", name)
-		return format.Print(response.Data)
+		cliutil.Fatal(cmd, fmt.Errorf("failed to create team: %w", err))
+		return
 	}
 
-	return fmt.Errorf("failed to create team: %s", response.Error)
+	format.PrintSuccess("✓ Team '%s' created successfully", name)
+	cliutil.Fatal(cmd, format.Print(response.Data))
 }
 
-func runDelete(cmd *cobra.Command, args []string) error {
+func runDelete(cmd *cobra.Command, args []string) {
 	name := args[0]
 
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
 
 	params := map[string]interface{}{
 		"name": name,
 	}
 
-	response, err := client.ExecuteStoredProcedure("DeleteTeam", params)
+	_, err = client.ExecuteStoredProcedure("DeleteTeam", params)
 	if err != nil {
-		return fmt.Errorf("failed to delete team: %w", err)
-	}
-
-	if response.Success {
-		format.PrintSuccess("✓ Team '%s' deleted successfully", name)
-		return nil
+		cliutil.Fatal(cmd, fmt.Errorf("failed to delete team: %w", err))
+		return
 	}
 
-	return fmt.Errorf("failed to delete team: %s", response.Error)
+	format.PrintSuccess("✓ Team '%s' deleted successfully", name)
 }
 
-func runRename(cmd *cobra.Command, args []string) error {
+func runRename(cmd *cobra.Command, args []string) {
 	oldName := args[0]
 	newName := args[1]
 
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
 
 	params := map[string]interface{}{
 		"oldName": oldName,
 		"newName": newName,
 	}
 
-	response, err := client.ExecuteStoredProcedure("UpdateTeamName", params)
+	_, err = client.ExecuteStoredProcedure("UpdateTeamName", params)
 	if err != nil {
-		return fmt.Errorf("failed to rename team: %w", err)
+		cliutil.Fatal(cmd, fmt.Errorf("failed to rename team: %w", err))
+		return
 	}
 
-	if response.Success {
-		format.PrintSuccess("✓ Team renamed from '%s' to '%s' successfully", oldName, newName)
-		return nil
-	}
-
-	return fmt.Errorf("failed to rename team: %s", response.Error)
+	format.PrintSuccess("✓ Team renamed from '%s' to '%s' successfully", oldName, newName)
 }
 
-func runMembersList(cmd *cobra.Command, args []string) error {
+func runMembersList(cmd *cobra.Command, args []string) {
 	teamName := args[0]
 
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
 
 	params := map[string]interface{}{
 		"name": teamName,
@@ -193,68 +229,63 @@ func runMembersList(cmd *cobra.Command, args []string) error {
 
 	response, err := client.ExecuteStoredProcedure("GetTeamMembers", params)
 	if err != nil {
-		return fmt.Errorf("failed to list team members: %w", err)
+		cliutil.Fatal(cmd, fmt.Errorf("failed to list team members: %w", err))
+		return
 	}
 
-	if response.Success {
-		if len(response.Data) == 0 {
-			fmt.Printf("No members found in team '%s'\n", teamName)
-			return nil
-		}
-		return format.Print(response.Data)
+	if len(response.Data) == 0 {
+		fmt.Printf("No members found in team '%s'\n", teamName)
+		return
 	}
-
-	return fmt.Errorf("failed to list team members: %s", response.Error)
+	cliutil.Fatal(cmd, format.Print(response.Data))
 }
 
-func runMembersAdd(cmd *cobra.Command, args []string) error {
+func runMembersAdd(cmd *cobra.Command, args []string) {
 	teamName := args[0]
 	userEmail := args[1]
 
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
 
 	params := map[string]interface{}{
 		"Team":         teamName,
 		"NewUserEmail": userEmail,
 	}
 
-	response, err := client.ExecuteStoredProcedure("AddUserToTeam", params)
+	_, err = client.ExecuteStoredProcedure("AddUserToTeam", params)
 	if err != nil {
-		return fmt.Errorf("failed to add user to team: %w", err)
-	}
-
-	if response.Success {
-		format.PrintSuccess("✓ User '%s' added to team '%s' successfully", userEmail, teamName)
-		return nil
+		cliutil.Fatal(cmd, fmt.Errorf("failed to add user to team: %w", err))
+		return
 	}
 
-	return fmt.Errorf("failed to add user to team: %s", response.Error)
+	format.PrintSuccess("✓ User '%s' added to team '%s' successfully", userEmail, teamName)
 }
 
-func runMembersRemove(cmd *cobra.Command, args []string) error {
+func runMembersRemove(cmd *cobra.Command, args []string) {
 	teamName := args[0]
 	userEmail := args[1]
 
-	cfg := config.Get()
-	client := api.NewClient(cfg.Server.URL)
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("failed to load session: %w", err))
+		return
+	}
 
 	params := map[string]interface{}{
 		"teamName":  teamName,
 		"userEmail": userEmail,
 	}
 
-	response, err := client.ExecuteStoredProcedure("DeleteUserFromTeam", params)
+	_, err = client.ExecuteStoredProcedure("DeleteUserFromTeam", params)
 	if err != nil {
-		return fmt.Errorf("failed to remove user from team: %w", err)
-	}
-
-	if response.Success {
-		format.PrintSuccess("✓ User '%s' removed from team '%s' successfully", userEmail, teamName)
-		return nil
+		cliutil.Fatal(cmd, fmt.Errorf("failed to remove user from team: %w", err))
+		return
 	}
 
-	return fmt.Errorf("failed to remove user from team: %s", response.Error)
+	format.PrintSuccess("✓ User '%s' removed from team '%s' successfully", userEmail, teamName)
 }
 
 func init() {
@@ -0,0 +1,340 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rediacc/cli/internal/api"
+)
+
+// refreshInterval is how often the active tab is silently re-fetched in the
+// background, in addition to the on-demand refresh bound to "r".
+const refreshInterval = 30 * time.Second
+
+// rowsPerPage caps how many rows of a list-shaped tab (currently only
+// Users) are shown at once; "n"/"p" page through the rest.
+const rowsPerPage = 15
+
+var (
+	tabBarStyle     = lipgloss.NewStyle().Padding(0, 1)
+	activeTabStyle  = tabBarStyle.Copy().Bold(true).Underline(true)
+	statusBarStyle  = lipgloss.NewStyle().Faint(true)
+	errorStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("9"))
+	headerCellStyle = lipgloss.NewStyle().Bold(true)
+)
+
+// tabState holds the last fetch result for one tab, independent of whether
+// it is currently the active one, so switching tabs doesn't lose data.
+type tabState struct {
+	loading bool
+	err     error
+	data    interface{}
+	page    int
+}
+
+type model struct {
+	client *api.Client
+
+	active int
+	tabs   []tabState
+
+	editing  bool
+	textarea textarea.Model
+
+	status string
+	width  int
+	height int
+}
+
+func newModel(client *api.Client) model {
+	ta := textarea.New()
+	ta.Placeholder = "{}"
+	ta.ShowLineNumbers = true
+
+	m := model{
+		client:   client,
+		tabs:     make([]tabState, len(tabs)),
+		textarea: ta,
+	}
+	for i := range m.tabs {
+		m.tabs[i].loading = true
+	}
+	return m
+}
+
+func (m model) Init() tea.Cmd {
+	cmds := make([]tea.Cmd, 0, len(tabs)+1)
+	for i := range tabs {
+		cmds = append(cmds, fetchTabCmd(m.client, i))
+	}
+	cmds = append(cmds, tickCmd())
+	return tea.Batch(cmds...)
+}
+
+type fetchResultMsg struct {
+	tabIndex int
+	data     interface{}
+	err      error
+}
+
+type tickMsg time.Time
+
+type vaultSavedMsg struct{ err error }
+
+func fetchTabCmd(client *api.Client, tabIndex int) tea.Cmd {
+	return func() tea.Msg {
+		data, err := tabs[tabIndex].fetch(client)
+		return fetchResultMsg{tabIndex: tabIndex, data: data, err: err}
+	}
+}
+
+func tickCmd() tea.Cmd {
+	return tea.Tick(refreshInterval, func(t time.Time) tea.Msg {
+		return tickMsg(t)
+	})
+}
+
+func saveVaultCmd(client *api.Client, raw string) tea.Cmd {
+	return func() tea.Msg {
+		return vaultSavedMsg{err: saveVault(client, raw)}
+	}
+}
+
+func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.textarea.SetWidth(msg.Width - 4)
+		m.textarea.SetHeight(msg.Height - 8)
+		return m, nil
+
+	case fetchResultMsg:
+		m.tabs[msg.tabIndex].loading = false
+		m.tabs[msg.tabIndex].err = msg.err
+		if msg.err == nil {
+			m.tabs[msg.tabIndex].data = msg.data
+		}
+		return m, nil
+
+	case tickMsg:
+		cmds := make([]tea.Cmd, 0, len(tabs)+1)
+		if !m.editing {
+			cmds = append(cmds, fetchTabCmd(m.client, m.active))
+		}
+		cmds = append(cmds, tickCmd())
+		return m, tea.Batch(cmds...)
+
+	case vaultSavedMsg:
+		if msg.err != nil {
+			m.status = errorStyle.Render(fmt.Sprintf("save failed: %s", msg.err))
+			return m, nil
+		}
+		m.editing = false
+		m.status = "vault saved"
+		vaultIndex := indexOfTab("vault")
+		m.tabs[vaultIndex].loading = true
+		return m, fetchTabCmd(m.client, vaultIndex)
+
+	case tea.KeyMsg:
+		if m.editing {
+			return m.updateEditing(msg)
+		}
+		return m.updateBrowsing(msg)
+	}
+	return m, nil
+}
+
+func (m model) updateBrowsing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c", "q":
+		return m, tea.Quit
+
+	case "tab", "right", "l":
+		m.active = (m.active + 1) % len(tabs)
+		m.status = ""
+		return m, nil
+
+	case "shift+tab", "left", "h":
+		m.active = (m.active - 1 + len(tabs)) % len(tabs)
+		m.status = ""
+		return m, nil
+
+	case "r":
+		m.tabs[m.active].loading = true
+		m.status = ""
+		return m, fetchTabCmd(m.client, m.active)
+
+	case "n":
+		m.tabs[m.active].page++
+		return m, nil
+
+	case "p":
+		if m.tabs[m.active].page > 0 {
+			m.tabs[m.active].page--
+		}
+		return m, nil
+
+	case "e":
+		if !tabs[m.active].editable {
+			return m, nil
+		}
+		raw, err := json.MarshalIndent(m.tabs[m.active].data, "", "  ")
+		if err != nil {
+			m.status = errorStyle.Render(fmt.Sprintf("cannot edit: %s", err))
+			return m, nil
+		}
+		m.textarea.SetValue(string(raw))
+		m.textarea.Focus()
+		m.editing = true
+		m.status = "editing vault — ctrl+s to save, esc to cancel"
+		return m, nil
+	}
+	return m, nil
+}
+
+func (m model) updateEditing(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.editing = false
+		m.textarea.Blur()
+		m.status = "edit cancelled"
+		return m, nil
+
+	case "ctrl+s":
+		raw := m.textarea.Value()
+		if !json.Valid([]byte(raw)) {
+			m.status = errorStyle.Render("not valid JSON, not saved")
+			return m, nil
+		}
+		m.status = "saving..."
+		return m, saveVaultCmd(m.client, raw)
+	}
+
+	var cmd tea.Cmd
+	m.textarea, cmd = m.textarea.Update(msg)
+	return m, cmd
+}
+
+func indexOfTab(key string) int {
+	for i, t := range tabs {
+		if t.key == key {
+			return i
+		}
+	}
+	return 0
+}
+
+func (m model) View() string {
+	var b strings.Builder
+	b.WriteString(m.renderTabBar())
+	b.WriteString("\n\n")
+
+	if m.editing {
+		b.WriteString(m.textarea.View())
+	} else {
+		b.WriteString(m.renderActiveTab())
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString(statusBarStyle.Render(m.renderHelp()))
+	return b.String()
+}
+
+func (m model) renderTabBar() string {
+	labels := make([]string, len(tabs))
+	for i, t := range tabs {
+		if i == m.active {
+			labels[i] = activeTabStyle.Render("[ " + t.title + " ]")
+		} else {
+			labels[i] = tabBarStyle.Render(t.title)
+		}
+	}
+	return strings.Join(labels, " ")
+}
+
+func (m model) renderActiveTab() string {
+	state := m.tabs[m.active]
+	if state.loading {
+		return "loading..."
+	}
+	if state.err != nil {
+		return errorStyle.Render(state.err.Error())
+	}
+	if state.data == nil {
+		return "(no data)"
+	}
+
+	switch data := state.data.(type) {
+	case []map[string]interface{}:
+		return renderPagedRows(data, state.page)
+	case map[string]interface{}:
+		return renderKeyValue(data)
+	default:
+		raw, _ := json.MarshalIndent(data, "", "  ")
+		return string(raw)
+	}
+}
+
+func renderKeyValue(row map[string]interface{}) string {
+	keys := make([]string, 0, len(row))
+	for k := range row {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s: %v\n", headerCellStyle.Render(k), row[k])
+	}
+	return b.String()
+}
+
+func renderPagedRows(rows []map[string]interface{}, page int) string {
+	if len(rows) == 0 {
+		return "(no rows)"
+	}
+
+	start := page * rowsPerPage
+	if start >= len(rows) {
+		start = 0
+	}
+	end := start + rowsPerPage
+	if end > len(rows) {
+		end = len(rows)
+	}
+
+	keys := make([]string, 0, len(rows[0]))
+	for k := range rows[0] {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	fmt.Fprintln(&b, headerCellStyle.Render(strings.Join(keys, "  ")))
+	for _, row := range rows[start:end] {
+		values := make([]string, len(keys))
+		for i, k := range keys {
+			values[i] = fmt.Sprintf("%v", row[k])
+		}
+		fmt.Fprintln(&b, strings.Join(values, "  "))
+	}
+	fmt.Fprintf(&b, "\n(rows %d-%d of %d)", start+1, end, len(rows))
+	return b.String()
+}
+
+func (m model) renderHelp() string {
+	if m.status != "" {
+		return m.status
+	}
+	if tabs[m.active].editable {
+		return "tab/shift+tab: switch tab   r: refresh   n/p: page   e: edit vault   q: quit"
+	}
+	return "tab/shift+tab: switch tab   r: refresh   n/p: page   q: quit"
+}
@@ -0,0 +1,84 @@
+package tui
+
+import (
+	"github.com/rediacc/cli/internal/api"
+)
+
+// tabDef describes one dashboard tab: its label and how to load its data.
+// fetch mirrors the stored procedure used by the equivalent `company`
+// subcommand, so the dashboard and the scripted CLI path never drift apart.
+type tabDef struct {
+	key      string
+	title    string
+	editable bool
+	fetch    func(client *api.Client) (interface{}, error)
+}
+
+// tabs lists the dashboard tabs in display order. The same order is used by
+// runNonInteractive for the non-TTY fallback.
+var tabs = []tabDef{
+	{key: "info", title: "Company Info", fetch: fetchInfo},
+	{key: "users", title: "Users", fetch: fetchUsers},
+	{key: "limits", title: "Resource Limits", fetch: fetchLimits},
+	{key: "vault", title: "Vault", editable: true, fetch: fetchVault},
+	{key: "subscription", title: "Subscription", fetch: fetchSubscription},
+}
+
+func fetchInfo(client *api.Client) (interface{}, error) {
+	resp, err := client.ExecuteStoredProcedure("GetUserCompanyDetails", map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 {
+		return nil, nil
+	}
+	return resp.Data[0], nil
+}
+
+func fetchUsers(client *api.Client) (interface{}, error) {
+	resp, err := client.ExecuteStoredProcedure("GetAllCompanyUsers", map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+func fetchLimits(client *api.Client) (interface{}, error) {
+	resp, err := client.ExecuteStoredProcedure("GetCompanyResourceLimits", map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 {
+		return nil, nil
+	}
+	return resp.Data[0], nil
+}
+
+func fetchVault(client *api.Client) (interface{}, error) {
+	resp, err := client.ExecuteStoredProcedure("GetCompanySecureData", map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 {
+		return nil, nil
+	}
+	return resp.Data[0], nil
+}
+
+func saveVault(client *api.Client, data string) error {
+	_, err := client.ExecuteStoredProcedure("UpdateCompanySecureData", map[string]interface{}{
+		"data": data,
+	})
+	return err
+}
+
+func fetchSubscription(client *api.Client) (interface{}, error) {
+	resp, err := client.ExecuteStoredProcedure("GetSubscriptionDetails", map[string]interface{}{})
+	if err != nil {
+		return nil, err
+	}
+	if len(resp.Data) == 0 {
+		return nil, nil
+	}
+	return resp.Data[0], nil
+}
@@ -0,0 +1,69 @@
+// Package tui provides an interactive dashboard that wraps the same
+// stored-procedure calls as the `company` command group (info, users list,
+// limits, vault get/update, subscription) into a single navigable surface,
+// instead of chaining many separate CLI invocations.
+package tui
+
+import (
+	"fmt"
+	"os"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/rediacc/cli/internal/api"
+	"github.com/rediacc/cli/internal/cliutil"
+	"github.com/rediacc/cli/internal/format"
+)
+
+// TuiCmd launches the interactive company dashboard.
+var TuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Interactive dashboard for company, user, and vault management",
+	Long: `Launch an interactive dashboard with tabs for company info, users,
+resource limits, the secure vault, and subscription details.
+
+Each tab is backed by the same stored procedures as the equivalent
+"company" subcommand, refreshed on demand or periodically, with inline
+editing of the vault's JSON contents. When stdout is not a terminal (for
+example when piped or redirected), it falls back to printing each section
+with the normal --output formatter instead of starting the program.`,
+	Run: runTui,
+}
+
+func runTui(cmd *cobra.Command, args []string) {
+	client, err := api.NewAuthenticatedClient(cmd.Context())
+	if err != nil {
+		cliutil.Fatal(cmd, err)
+		return
+	}
+
+	if !term.IsTerminal(int(os.Stdout.Fd())) {
+		cliutil.Fatal(cmd, runNonInteractive(client))
+		return
+	}
+
+	program := tea.NewProgram(newModel(client), tea.WithAltScreen())
+	if _, err := program.Run(); err != nil {
+		cliutil.Fatal(cmd, fmt.Errorf("tui: %w", err))
+	}
+}
+
+// runNonInteractive prints every tab's data once, in the same order they
+// appear in the dashboard, using the configured --output formatter. It is
+// the degraded path for piped/redirected stdout, where an interactive
+// program cannot render.
+func runNonInteractive(client *api.Client) error {
+	for _, tab := range tabs {
+		data, err := tab.fetch(client)
+		if err != nil {
+			return fmt.Errorf("%s: %w", tab.title, err)
+		}
+		format.PrintInfo("== %s ==", tab.title)
+		if err := format.Print(data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+)
+
+// GenerateCSR creates a fresh ECDSA P-256 key pair and a PKCS#10 certificate
+// signing request for commonName, with sans attached as DNS SANs (used for
+// SPIFFE-style identifiers like "spiffe://rediacc/ci-runner/prod"). It
+// returns the CSR and private key, both PEM-encoded, for `auth cert-enroll`
+// to submit and persist respectively.
+func GenerateCSR(commonName string, sans []string) (csrPEM, keyPEM []byte, err error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to generate client key: %w", err)
+	}
+
+	template := x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: commonName},
+		DNSNames: sans,
+	}
+
+	csrDER, err := x509.CreateCertificateRequest(rand.Reader, &template, key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create certificate signing request: %w", err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal client key: %w", err)
+	}
+
+	csrPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: csrDER})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return csrPEM, keyPEM, nil
+}
@@ -2,15 +2,22 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/rediacc/cli/internal/config"
+	"github.com/rediacc/cli/internal/utils"
 )
 
 // Client represents the API client
@@ -18,17 +25,145 @@ type Client struct {
 	BaseURL    string
 	HTTPClient *http.Client
 	config     *config.Config
+
+	// configMu guards config's Auth fields (and config itself): a single
+	// Client is shared across goroutines by bulk.RunPool, and
+	// updateTokenFromResponse rewrites SessionToken/RequestCredential after
+	// every response, concurrently with maybeRefresh/setAuthHeaders/
+	// RefreshToken/IsAuthenticated reading them. Use authSnapshot/
+	// setAuthFields instead of touching c.config.Auth directly.
+	configMu sync.RWMutex
+
+	// mtlsConfigured is set by NewClientWithCert; setAuthHeaders uses it to
+	// fall back to the mTLS identity when RequestCredential is empty.
+	mtlsConfigured bool
+
+	// tokens tracks RequestCredential's expiry and serializes refreshes
+	// triggered by concurrent ExecuteStoredProcedure calls on this Client.
+	tokens *tokenManager
+}
+
+// authSnapshot returns a copy of the client's current auth fields. Safe to
+// call concurrently with setAuthFields/Login/Logout from other goroutines
+// sharing this Client.
+func (c *Client) authSnapshot() config.AuthConfig {
+	c.configMu.RLock()
+	defer c.configMu.RUnlock()
+	return c.config.Auth
+}
+
+// setAuthFields updates the client's in-memory session token/credential.
+// Safe to call concurrently with authSnapshot/setAuthHeaders from other
+// goroutines sharing this Client.
+func (c *Client) setAuthFields(sessionToken, requestCredential string) {
+	c.configMu.Lock()
+	defer c.configMu.Unlock()
+	c.config.Auth.SessionToken = sessionToken
+	c.config.Auth.RequestCredential = requestCredential
 }
 
-// NewClient creates a new API client
+// ClientConfig configures mTLS client-certificate authentication for
+// NewClientWithCert: a signed certificate and key, and optionally a CA
+// bundle to verify the server with and a SAN (e.g. a SPIFFE ID) that must
+// appear in the server's certificate.
+type ClientConfig struct {
+	CertFile string
+	KeyFile  string
+	CAFile   string
+	// ServerName overrides the TLS ServerName / SAN verification, for
+	// SPIFFE-style identifiers that don't match the server's DNS name.
+	ServerName string
+}
+
+// NewClient creates a new API client. Its HTTPClient's Transport is the
+// retry/rate-limit/circuit-breaker/logging pipeline built by buildTransport,
+// so every stored-procedure call gets that resilience without callers having
+// to opt in.
 func NewClient(baseURL string) *Client {
+	cfg := config.Get()
 	return &Client{
 		BaseURL: baseURL,
 		HTTPClient: &http.Client{
-			Timeout: 30 * time.Second,
+			Timeout:   30 * time.Second,
+			Transport: buildTransport(http.DefaultTransport, cfg),
 		},
-		config: config.Get(),
+		config: cfg,
+		tokens: newTokenManager(),
+	}
+}
+
+// NewAuthenticatedClient creates an API client for the current server whose
+// RequestCredential is sourced from the configured credstore.Store backend
+// (see config.CredentialsBackend) when the session was established via
+// `rediacc auth login`, rather than from the plaintext config file. The
+// context is accepted for symmetry with the job-polling and refresh flows
+// that build on this client and is not yet wired into individual HTTP
+// requests.
+func NewAuthenticatedClient(ctx context.Context) (*Client, error) {
+	cfg := config.Get()
+	client := NewClient(cfg.Server.URL)
+
+	if cfg.Auth.Email == "" {
+		return client, nil
+	}
+
+	cred, err := loadCredential(cfg.Server.URL, cfg.Auth.Email)
+	if err != nil {
+		// No session credential on file: if cert-enroll/cert-login set up
+		// an mTLS identity, prefer it over a session-less plaintext client.
+		if cfg.Auth.ClientCertFile != "" && cfg.Auth.ClientKeyFile != "" {
+			return NewClientWithCert(cfg.Server.URL, ClientConfig{
+				CertFile: cfg.Auth.ClientCertFile,
+				KeyFile:  cfg.Auth.ClientKeyFile,
+				CAFile:   cfg.Auth.CAFile,
+			})
+		}
+		// Fall back to whatever is already in the config file; this keeps
+		// the client usable on installs predating the keyring-backed store.
+		return client, nil
 	}
+
+	authedConfig := *cfg
+	authedConfig.Auth.SessionToken = cred.SessionToken
+	authedConfig.Auth.RequestCredential = cred.RequestCredential
+	client.config = &authedConfig
+
+	return client, nil
+}
+
+// NewClientWithCert creates an API client whose HTTPClient presents the
+// certificate/key from certCfg on every TLS handshake, for the mTLS
+// machine-auth mode set up by `auth cert-enroll`/`auth cert-login`. It is
+// used instead of (not layered on top of) NewAuthenticatedClient, since the
+// two represent separate auth modes.
+func NewClientWithCert(baseURL string, certCfg ClientConfig) (*Client, error) {
+	cert, err := tls.LoadX509KeyPair(certCfg.CertFile, certCfg.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+	}
+	if certCfg.ServerName != "" {
+		tlsConfig.ServerName = certCfg.ServerName
+	}
+	if certCfg.CAFile != "" {
+		caPEM, err := os.ReadFile(certCfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", certCfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	client := NewClient(baseURL)
+	client.HTTPClient.Transport = buildTransport(&http.Transport{TLSClientConfig: tlsConfig}, client.config)
+	client.mtlsConfigured = true
+	return client, nil
 }
 
 // Request represents an API request
@@ -44,6 +179,11 @@ type Response struct {
 	Data    []map[string]interface{} `json:"data"`
 	Error   string                   `json:"error,omitempty"`
 	Message string                   `json:"message,omitempty"`
+
+	// Outputs carries MiddlewareResponse.Outputs through unchanged, for
+	// callers that need scalar results a procedure returned outside its
+	// table data, e.g. the job_id ExecuteJob polls on.
+	Outputs map[string]interface{} `json:"outputs,omitempty"`
 }
 
 // AuthResponse represents an authentication response
@@ -53,6 +193,12 @@ type AuthResponse struct {
 	RequestCredential string `json:"request_credential"`
 	Message           string `json:"message"`
 	Error             string `json:"error,omitempty"`
+
+	// CertificatePEM/CABundlePEM are populated by EnrollCertificate: the
+	// middleware's signed client certificate and, if it runs its own CA,
+	// the bundle to verify the server with.
+	CertificatePEM string `json:"certificate_pem,omitempty"`
+	CABundlePEM    string `json:"ca_bundle_pem,omitempty"`
 }
 
 // MiddlewareResponse represents the middleware API response format
@@ -69,8 +215,18 @@ type MiddlewareTable struct {
 	Data           []map[string]interface{} `json:"data"`
 }
 
-// ExecuteStoredProcedure executes a stored procedure via the middleware API
+// ExecuteStoredProcedure executes a stored procedure via the middleware API,
+// proactively rotating RequestCredential first if it's within its refresh
+// skew of expiring.
 func (c *Client) ExecuteStoredProcedure(procedure string, params map[string]interface{}) (*Response, error) {
+	c.maybeRefresh()
+	return c.executeStoredProcedureRaw(procedure, params)
+}
+
+// executeStoredProcedureRaw is ExecuteStoredProcedure without the proactive
+// refresh check, so RefreshToken itself (which calls this directly for
+// RefreshAuthenticationRequest) doesn't recurse into refreshing.
+func (c *Client) executeStoredProcedureRaw(procedure string, params map[string]interface{}) (*Response, error) {
 	// Convert parameters to JSON
 	jsonData, err := json.Marshal(params)
 	if err != nil {
@@ -109,15 +265,12 @@ func (c *Client) ExecuteStoredProcedure(procedure string, params map[string]inte
 
 	// Handle HTTP errors
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
+		return nil, utils.NewAPIError(resp.StatusCode, fmt.Sprintf("HTTP error: %d", resp.StatusCode), "HTTP_ERROR")
 	}
 
 	// Check for API errors
 	if middlewareResp.Failure != 0 {
-		if len(middlewareResp.Errors) > 0 {
-			return nil, fmt.Errorf("API error: %s", middlewareResp.Errors[0])
-		}
-		return nil, fmt.Errorf("API error: failure code %d", middlewareResp.Failure)
+		return nil, apiErrorFromResponse(resp.StatusCode, &middlewareResp)
 	}
 
 	// Check for token refresh (nextReqeustCredential in response)
@@ -127,6 +280,7 @@ func (c *Client) ExecuteStoredProcedure(procedure string, params map[string]inte
 	response := &Response{
 		Success: middlewareResp.Failure == 0,
 		Data:    []map[string]interface{}{},
+		Outputs: middlewareResp.Outputs,
 	}
 	
 	// Flatten all table data into the Data field
@@ -137,11 +291,16 @@ func (c *Client) ExecuteStoredProcedure(procedure string, params map[string]inte
 	return response, nil
 }
 
-// Login authenticates the user and stores the session information
-func (c *Client) Login(email, password string) (*AuthResponse, error) {
+// Login authenticates the user, optionally completing a 2FA challenge via
+// twoFACode, and persists the resulting session to the configured
+// credstore.Store backend (see config.CredentialsBackend).
+func (c *Client) Login(email, password, twoFACode string) (*AuthResponse, error) {
 	params := map[string]interface{}{
 		"name": "{ }",  // Required parameter for CreateAuthenticationRequest
 	}
+	if twoFACode != "" {
+		params["TwoFACode"] = twoFACode
+	}
 
 	// Execute login procedure (this will be routed to protected_CreateAuthenticationRequest)
 	response, err := c.executeAuthProcedure("CreateAuthenticationRequest", params, email, password)
@@ -149,44 +308,86 @@ func (c *Client) Login(email, password string) (*AuthResponse, error) {
 		return nil, err
 	}
 
-	// Update configuration with auth info
-	if response.Success {
-		if err := config.UpdateAuth(email, response.SessionToken, response.RequestCredential); err != nil {
-			return nil, fmt.Errorf("failed to save authentication info: %w", err)
-		}
+	// Persist auth info. A nil error here always means a successful login:
+	// executeAuthProcedure returns an error on any middleware failure.
+	if err := saveCredential(c.BaseURL, email, response.SessionToken, response.RequestCredential); err != nil {
+		return nil, fmt.Errorf("failed to save authentication info: %w", err)
+	}
 
-		// Update client config reference
-		c.config = config.Get()
+	// The plaintext config file only tracks which email is active; the
+	// actual token material lives in the credential store above.
+	if err := config.UpdateAuth(email, "", ""); err != nil {
+		return nil, fmt.Errorf("failed to save authentication info: %w", err)
 	}
 
+	// Update client config reference
+	c.configMu.Lock()
+	c.config = config.Get()
+	c.config.Auth.SessionToken = response.SessionToken
+	c.config.Auth.RequestCredential = response.RequestCredential
+	c.configMu.Unlock()
+
 	return response, nil
 }
 
-// Logout logs out the current user session
+// Logout logs out the current user session and clears the stored credential.
 func (c *Client) Logout() error {
-	if c.config.Auth.RequestCredential == "" {
+	auth := c.authSnapshot()
+	if auth.RequestCredential == "" {
 		return fmt.Errorf("not logged in")
 	}
 
+	email := auth.Email
+
 	// Call LogoutUserSession procedure - this is public, so no params needed
 	_, err := c.ExecuteStoredProcedure("LogoutUserSession", map[string]interface{}{})
 	if err != nil {
 		return err
 	}
 
+	if err := clearCredential(c.BaseURL, email); err != nil {
+		return err
+	}
+
 	// Clear auth info
 	return config.ClearAuth()
 }
 
-// RefreshToken refreshes the authentication token
+// maybeRefresh proactively rotates RequestCredential when tokenManager says
+// it's close to expiring. A refresh failure here is not fatal: the caller's
+// actual request still goes out with whatever credential is on hand, and
+// surfaces its own auth error if that credential turns out to be expired.
+func (c *Client) maybeRefresh() {
+	auth := c.authSnapshot()
+	if auth.Email == "" || auth.RequestCredential == "" {
+		return
+	}
+	if !c.tokens.needsRefresh() {
+		return
+	}
+	_ = c.RefreshToken()
+}
+
+// RefreshToken forces a rotation of RequestCredential via
+// RefreshAuthenticationRequest. Concurrent callers on the same Client are
+// serialized by tokenManager, so at most one request goes out.
 func (c *Client) RefreshToken() error {
-	if c.config.Auth.Email == "" {
+	auth := c.authSnapshot()
+	if auth.Email == "" {
 		return fmt.Errorf("no email configured for token refresh")
 	}
 
-	// For now, we don't have a specific refresh endpoint
-	// This would need to be implemented based on the middleware API
-	return fmt.Errorf("token refresh not implemented")
+	_, err := c.tokens.refresh(func() (*AuthResponse, error) {
+		requestCredential := c.authSnapshot().RequestCredential
+		_, err := c.executeStoredProcedureRaw("RefreshAuthenticationRequest", map[string]interface{}{
+			"requestCredential": requestCredential,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &AuthResponse{Success: true, RequestCredential: requestCredential}, nil
+	})
+	return err
 }
 
 // executeAuthProcedure executes an authentication-related procedure  
@@ -231,43 +432,65 @@ func (c *Client) executeAuthProcedure(procedure string, params map[string]interf
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP error: %d", resp.StatusCode)
+		return nil, utils.NewAPIError(resp.StatusCode, fmt.Sprintf("HTTP error: %d", resp.StatusCode), "HTTP_ERROR")
 	}
 
 	// Check for API errors
 	if middlewareResp.Failure != 0 {
-		if len(middlewareResp.Errors) > 0 {
-			return nil, fmt.Errorf("API error: %s", middlewareResp.Errors[0])
-		}
-		return nil, fmt.Errorf("API error: failure code %d", middlewareResp.Failure)
+		return nil, apiErrorFromResponse(resp.StatusCode, &middlewareResp)
 	}
 
-	// Extract the nextReqeustCredential from the first table
+	// Extract the nextReqeustCredential (and, for EnrollCertificate, the
+	// signed certificate/CA bundle) from the first table
 	authResponse := &AuthResponse{Success: true}
 	if len(middlewareResp.Tables) > 0 && len(middlewareResp.Tables[0].Data) > 0 {
-		if cred, ok := middlewareResp.Tables[0].Data[0]["nextReqeustCredential"]; ok {
-			if credStr, ok := cred.(string); ok {
-				authResponse.RequestCredential = credStr
-				authResponse.SessionToken = credStr // Use the same for both for now
-			}
+		row := middlewareResp.Tables[0].Data[0]
+		if cred, ok := row["nextReqeustCredential"].(string); ok {
+			authResponse.RequestCredential = cred
+			authResponse.SessionToken = cred // Use the same for both for now
+		}
+		if cert, ok := row["signedCertificatePem"].(string); ok {
+			authResponse.CertificatePEM = cert
+		}
+		if ca, ok := row["caBundlePem"].(string); ok {
+			authResponse.CABundlePEM = ca
 		}
 	}
 
 	return authResponse, nil
 }
 
+// EnrollCertificate submits csrPEM to the middleware's certificate
+// enrollment procedure, authenticating the request with email/password the
+// same way Login does. The returned AuthResponse carries the signed client
+// certificate (and CA bundle, if any) instead of a session token.
+func (c *Client) EnrollCertificate(email, password string, csrPEM []byte) (*AuthResponse, error) {
+	params := map[string]interface{}{
+		"csr": string(csrPEM),
+	}
+	return c.executeAuthProcedure("EnrollClientCertificate", params, email, password)
+}
+
 // updateTokenFromResponse updates the stored token from API response
 func (c *Client) updateTokenFromResponse(resp *MiddlewareResponse) {
+	if exp, ok := parseExpiry(resp.Outputs); ok {
+		c.tokens.setExpiry(exp)
+	}
+
 	// Look for nextReqeustCredential in any table data
 	for _, table := range resp.Tables {
 		for _, row := range table.Data {
 			if cred, ok := row["nextReqeustCredential"]; ok {
 				if credStr, ok := cred.(string); ok {
-					// Update both session token and request credential
-					if err := config.UpdateAuth(c.config.Auth.Email, credStr, credStr); err == nil {
-						// Refresh client config reference
-						c.config = config.Get()
+					// Rotate the credential in whichever backend holds it, not
+					// config.Auth in the plaintext YAML, since that store
+					// (not the config file) is where session material lives.
+					// saveCredential's backend locks across processes that
+					// might be refreshing the same email concurrently.
+					if email := c.authSnapshot().Email; email != "" {
+						_ = saveCredential(c.BaseURL, email, credStr, credStr)
 					}
+					c.setAuthFields(credStr, credStr)
 					return
 				}
 			}
@@ -275,15 +498,67 @@ func (c *Client) updateTokenFromResponse(resp *MiddlewareResponse) {
 	}
 }
 
+// apiErrorFromResponse decodes a failed middleware response into a
+// *utils.APIError. Middleware errors are plain strings; when one is
+// formatted as "CODE: message" (an all-caps, underscore-separated prefix)
+// the code is split out so scripted callers can match on it instead of the
+// message text. Any Outputs the middleware returned alongside the failure
+// are attached as Details.
+func apiErrorFromResponse(statusCode int, resp *MiddlewareResponse) *utils.APIError {
+	message := fmt.Sprintf("failure code %d", resp.Failure)
+	code := ""
+	if len(resp.Errors) > 0 {
+		message = resp.Errors[0]
+		if prefix, rest, ok := strings.Cut(message, ":"); ok && isErrorCode(prefix) {
+			code = prefix
+			message = strings.TrimSpace(rest)
+		}
+	}
+
+	return utils.NewAPIError(statusCode, message, code).WithDetails(resp.Outputs)
+}
+
+// isErrorCode reports whether s looks like a machine-readable error code
+// (e.g. "COMPANY_EXISTS") rather than the start of a prose sentence.
+func isErrorCode(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r != '_' && !(r >= 'A' && r <= 'Z') && !(r >= '0' && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
 // setAuthHeaders sets the authentication headers based on the middleware requirements
 func (c *Client) setAuthHeaders(req *http.Request) {
 	// Use RequestToken header for authenticated requests
-	if c.config.Auth.RequestCredential != "" {
-		req.Header.Set("Rediacc-RequestToken", c.config.Auth.RequestCredential)
+	if cred := c.authSnapshot().RequestCredential; cred != "" {
+		req.Header.Set("Rediacc-RequestToken", cred)
+		return
+	}
+
+	// No session credential: fall back to the mTLS identity presented at
+	// the TLS layer, so the middleware knows to authenticate this request
+	// from the client certificate instead of expecting a RequestToken.
+	if c.mtlsConfigured {
+		req.Header.Set("Rediacc-ClientCertAuth", "true")
 	}
 }
 
 // IsAuthenticated checks if the client has valid authentication
 func (c *Client) IsAuthenticated() bool {
-	return c.config.Auth.Email != "" && c.config.Auth.SessionToken != ""
+	auth := c.authSnapshot()
+	return auth.Email != "" && auth.SessionToken != ""
+}
+
+// RequestCredential returns the credential this client currently
+// authenticates with, for callers (e.g. the terminal WebSocket upgrade)
+// that need to set an auth header outside ExecuteStoredProcedure's own
+// setAuthHeaders. It reflects NewAuthenticatedClient's credstore-backed
+// session, not the plaintext config file.
+func (c *Client) RequestCredential() string {
+	return c.authSnapshot().RequestCredential
 }
@@ -0,0 +1,55 @@
+package api
+
+import (
+	"github.com/rediacc/cli/internal/config"
+	"github.com/rediacc/cli/internal/credstore"
+)
+
+// store resolves the credstore.Store backend selected by
+// config.CredentialsBackend for the current invocation.
+func store() credstore.Store {
+	return credstore.Select(config.CredentialsBackend())
+}
+
+// saveCredential persists a credential via the configured credstore backend.
+func saveCredential(serverURL, email, sessionToken, requestCredential string) error {
+	return store().Set(serverURL, email, credstore.Credential{
+		SessionToken:      sessionToken,
+		RequestCredential: requestCredential,
+	})
+}
+
+// loadCredential reads a previously saved credential from the configured
+// credstore backend. If none is there but the plaintext config file still
+// carries session material from a CLI version that predates credstore, it
+// migrates that credential into the backend and zero-fills the config file.
+func loadCredential(serverURL, email string) (*credstore.Credential, error) {
+	s := store()
+
+	cred, err := s.Get(serverURL, email)
+	if err == nil {
+		return cred, nil
+	}
+
+	cfg := config.Get()
+	if cfg.Auth.RequestCredential == "" {
+		return nil, err
+	}
+
+	migrated, migrateErr := credstore.MigrateLegacy(s, serverURL, email, cfg.Auth.SessionToken, cfg.Auth.RequestCredential)
+	if migrateErr != nil || !migrated {
+		return nil, err
+	}
+
+	legacy := &credstore.Credential{
+		SessionToken:      cfg.Auth.SessionToken,
+		RequestCredential: cfg.Auth.RequestCredential,
+	}
+	_ = config.UpdateAuth(email, "", "")
+	return legacy, nil
+}
+
+// clearCredential removes a saved credential from the configured backend.
+func clearCredential(serverURL, email string) error {
+	return store().Delete(serverURL, email)
+}
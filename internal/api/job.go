@@ -0,0 +1,71 @@
+package api
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// jobPollInterval is how long ExecuteJob waits between GetJobStatus polls.
+const jobPollInterval = 2 * time.Second
+
+// Terminal states GetJobStatus reports for an asynchronous job.
+const (
+	JobStatusCompleted = "Completed"
+	JobStatusFailed    = "Failed"
+	JobStatusCancelled = "Cancelled"
+)
+
+// JobResult is the terminal state of a job started via ExecuteJob: either
+// the immediate result of a synchronous procedure, or the last GetJobStatus
+// poll of an asynchronous one.
+type JobResult struct {
+	JobID   string
+	Status  string
+	Data    []map[string]interface{}
+	Outputs map[string]interface{}
+}
+
+// ExecuteJob executes procedure via ExecuteStoredProcedure, then, if the
+// middleware responds with a job_id in Outputs rather than an immediate
+// result, long-polls GetJobStatus until the job reaches a terminal state,
+// writing a progress line to stderr after each poll. It is the entry point
+// the `jobs` command group uses for operations (machine start/stop, repo
+// clone/sync, plugin install, ...) long enough to run asynchronously on
+// the middleware.
+func (c *Client) ExecuteJob(procedure string, params map[string]interface{}) (*JobResult, error) {
+	response, err := c.ExecuteStoredProcedure(procedure, params)
+	if err != nil {
+		return nil, err
+	}
+
+	jobID, _ := response.Outputs["job_id"].(string)
+	if jobID == "" {
+		return &JobResult{Status: JobStatusCompleted, Data: response.Data, Outputs: response.Outputs}, nil
+	}
+
+	return c.pollJob(jobID)
+}
+
+// pollJob polls GetJobStatus for jobID until it reports a terminal state.
+func (c *Client) pollJob(jobID string) (*JobResult, error) {
+	for {
+		response, err := c.ExecuteStoredProcedure("GetJobStatus", map[string]interface{}{"jobId": jobID})
+		if err != nil {
+			return nil, fmt.Errorf("polling job %s: %w", jobID, err)
+		}
+
+		status, _ := response.Outputs["status"].(string)
+		fmt.Fprintf(os.Stderr, "job %s: %s\n", jobID, status)
+
+		switch status {
+		case JobStatusCompleted:
+			return &JobResult{JobID: jobID, Status: status, Data: response.Data, Outputs: response.Outputs}, nil
+		case JobStatusFailed, JobStatusCancelled:
+			return nil, fmt.Errorf("job %s %s", jobID, strings.ToLower(status))
+		}
+
+		time.Sleep(jobPollInterval)
+	}
+}
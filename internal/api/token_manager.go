@@ -0,0 +1,75 @@
+package api
+
+import (
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// defaultRefreshSkew is how far ahead of RequestCredential's expiry a
+// proactive refresh is triggered.
+const defaultRefreshSkew = 30 * time.Second
+
+// tokenManager tracks RequestCredential's expiry for one Client and
+// serializes concurrent refreshes behind a singleflight.Group, so parallel
+// ExecuteStoredProcedure calls from a long-running process (the `jobs`
+// long-poll loop, a daemonized CLI) trigger at most one refresh instead of
+// racing each other.
+type tokenManager struct {
+	mu        sync.Mutex
+	expiresAt time.Time
+	skew      time.Duration
+	group     singleflight.Group
+}
+
+func newTokenManager() *tokenManager {
+	return &tokenManager{skew: defaultRefreshSkew}
+}
+
+// setExpiry records when the current RequestCredential expires, parsed
+// from the middleware's response (see parseExpiry). A zero time means
+// "unknown expiry", and needsRefresh never triggers from it alone.
+func (tm *tokenManager) setExpiry(exp time.Time) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	tm.expiresAt = exp
+}
+
+// needsRefresh reports whether the credential is within tm.skew of
+// expiring (or already expired).
+func (tm *tokenManager) needsRefresh() bool {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+	return !tm.expiresAt.IsZero() && !time.Now().Add(tm.skew).Before(tm.expiresAt)
+}
+
+// refresh runs fn to completion at most once across concurrent callers,
+// returning the same result to all of them.
+func (tm *tokenManager) refresh(fn func() (*AuthResponse, error)) (*AuthResponse, error) {
+	v, err, _ := tm.group.Do("refresh", func() (interface{}, error) {
+		return fn()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*AuthResponse), nil
+}
+
+// parseExpiry reads an "exp" entry from a MiddlewareResponse's Outputs, as
+// either a Unix timestamp (number) or an RFC3339 string.
+func parseExpiry(outputs map[string]interface{}) (time.Time, bool) {
+	raw, ok := outputs["exp"]
+	if !ok {
+		return time.Time{}, false
+	}
+	switch v := raw.(type) {
+	case float64:
+		return time.Unix(int64(v), 0), true
+	case string:
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
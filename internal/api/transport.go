@@ -0,0 +1,144 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/sony/gobreaker"
+	"golang.org/x/time/rate"
+
+	"github.com/rediacc/cli/internal/config"
+)
+
+// buildTransport assembles the http.RoundTripper pipeline every Client's
+// HTTPClient uses: circuit breaker -> rate limiter -> request logging ->
+// retry with exponential backoff, wrapping base (the transport that actually
+// dials). Order matters: the breaker must see (and short-circuit ahead of)
+// a request before a single retry attempt is made, and logging should see
+// every attempt the retry layer makes, not just the final outcome.
+func buildTransport(base http.RoundTripper, cfg *config.Config) http.RoundTripper {
+	t := retryTransport(base, cfg.Retry)
+	t = loggingTransport(t)
+	t = rateLimitTransport(t, cfg.RateLimit)
+	t = breakerTransport(t, cfg.Breaker)
+	return t
+}
+
+// retryTransport wraps base with go-retryablehttp's exponential backoff and
+// jitter, retrying 5xx/429 responses and connection errors (and honoring a
+// Retry-After header) up to cfg.MaxAttempts times.
+func retryTransport(base http.RoundTripper, cfg config.RetryConfig) http.RoundTripper {
+	client := retryablehttp.NewClient()
+	client.HTTPClient.Transport = base
+	client.RetryMax = cfg.MaxAttempts
+	client.Logger = nil // loggingTransport covers --verbose; retryablehttp's own logger would duplicate it
+	if wait, err := time.ParseDuration(cfg.InitialBackoff); err == nil && wait > 0 {
+		client.RetryWaitMin = wait
+		client.RetryWaitMax = wait << uint(cfg.MaxAttempts)
+	}
+	return &retryablehttp.RoundTripper{Client: client}
+}
+
+// loggingRoundTripper prints each request and its outcome to stderr when
+// --verbose is set, for diagnosing what the API client actually sent.
+type loggingRoundTripper struct {
+	next http.RoundTripper
+}
+
+func loggingTransport(next http.RoundTripper) http.RoundTripper {
+	return &loggingRoundTripper{next: next}
+}
+
+func (t *loggingRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !config.IsVerbose() {
+		return t.next.RoundTrip(req)
+	}
+
+	start := time.Now()
+	fmt.Fprintf(os.Stderr, "--> %s %s\n", req.Method, req.URL)
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "<-- %s %s error: %v (%s)\n", req.Method, req.URL, err, time.Since(start))
+		return nil, err
+	}
+	fmt.Fprintf(os.Stderr, "<-- %s %s %s (%s)\n", req.Method, req.URL, resp.Status, time.Since(start))
+	return resp, nil
+}
+
+// rateLimitedTransport enforces a token-bucket rate limit per destination
+// host, so a bulk command doesn't hammer the middleware faster than cfg.RPS.
+type rateLimitedTransport struct {
+	next http.RoundTripper
+	rps  float64
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+func rateLimitTransport(next http.RoundTripper, cfg config.RateLimitConfig) http.RoundTripper {
+	if cfg.RPS <= 0 {
+		return next
+	}
+	return &rateLimitedTransport{next: next, rps: cfg.RPS, limiters: make(map[string]*rate.Limiter)}
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiterFor(req.URL.Host).Wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.next.RoundTrip(req)
+}
+
+func (t *rateLimitedTransport) limiterFor(host string) *rate.Limiter {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	limiter, ok := t.limiters[host]
+	if !ok {
+		burst := int(t.rps)
+		if burst < 1 {
+			burst = 1
+		}
+		limiter = rate.NewLimiter(rate.Limit(t.rps), burst)
+		t.limiters[host] = limiter
+	}
+	return limiter
+}
+
+// breakerRoundTripper opens a per-Client circuit breaker after cfg.Threshold
+// consecutive request failures (after the retry layer has exhausted its
+// attempts), short-circuiting further requests with a clear error instead of
+// letting them queue up against a host that's already down.
+type breakerRoundTripper struct {
+	next http.RoundTripper
+	cb   *gobreaker.CircuitBreaker
+}
+
+func breakerTransport(next http.RoundTripper, cfg config.BreakerConfig) http.RoundTripper {
+	if cfg.Threshold <= 0 {
+		return next
+	}
+	settings := gobreaker.Settings{
+		Name: "rediacc-api",
+		ReadyToTrip: func(counts gobreaker.Counts) bool {
+			return counts.ConsecutiveFailures >= uint32(cfg.Threshold)
+		},
+	}
+	return &breakerRoundTripper{next: next, cb: gobreaker.NewCircuitBreaker(settings)}
+}
+
+func (t *breakerRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	result, err := t.cb.Execute(func() (interface{}, error) {
+		return t.next.RoundTrip(req)
+	})
+	if err != nil {
+		if err == gobreaker.ErrOpenState || err == gobreaker.ErrTooManyRequests {
+			return nil, fmt.Errorf("circuit breaker open for %s: %w", req.URL.Host, err)
+		}
+		return nil, err
+	}
+	return result.(*http.Response), nil
+}
@@ -0,0 +1,152 @@
+// Package bulk provides shared helpers for the JSONL import/export and
+// worker-pool patterns used by the bulk admin commands (teams export/import,
+// auth user export/import, and similar).
+package bulk
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// OnConflict controls how an importer reacts to a record that already
+// exists on the server.
+type OnConflict string
+
+const (
+	OnConflictSkip   OnConflict = "skip"
+	OnConflictUpdate OnConflict = "update"
+	OnConflictFail   OnConflict = "fail"
+)
+
+// ParseOnConflict validates a --on-conflict flag value.
+func ParseOnConflict(value string) (OnConflict, error) {
+	switch OnConflict(value) {
+	case OnConflictSkip, OnConflictUpdate, OnConflictFail:
+		return OnConflict(value), nil
+	default:
+		return "", fmt.Errorf("invalid --on-conflict value %q (expected skip, update, or fail)", value)
+	}
+}
+
+// ReadJSONL reads newline-delimited JSON objects from path, skipping blank lines.
+func ReadJSONL(path string) ([]map[string]interface{}, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var records []map[string]interface{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var record map[string]interface{}
+		if err := json.Unmarshal(line, &record); err != nil {
+			return nil, fmt.Errorf("failed to parse JSONL line: %w", err)
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return records, nil
+}
+
+// WriteJSONL writes records as newline-delimited JSON to w, one per record.
+func WriteJSONL(w *bufio.Writer, records []map[string]interface{}) error {
+	enc := json.NewEncoder(w)
+	for _, record := range records {
+		if err := enc.Encode(record); err != nil {
+			return fmt.Errorf("failed to encode JSONL record: %w", err)
+		}
+	}
+	return w.Flush()
+}
+
+// Result captures the outcome of a single item processed through RunPool.
+type Result struct {
+	Item    interface{} `json:"item"`
+	Success bool        `json:"success"`
+	Error   string      `json:"error,omitempty"`
+}
+
+// RunPool runs fn over items using up to parallelism concurrent workers,
+// returning one Result per item in input order. A parallelism below 1 is
+// treated as 1 (sequential).
+func RunPool[T any](items []T, parallelism int, fn func(T) error) []Result {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	results := make([]Result, len(items))
+	sem := make(chan struct{}, parallelism)
+	var wg sync.WaitGroup
+
+	for i, item := range items {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, item T) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			err := fn(item)
+			results[i] = Result{Item: item, Success: err == nil}
+			if err != nil {
+				results[i].Error = err.Error()
+			}
+		}(i, item)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// WriteReport writes per-record results to path as JSONL, for --report flags.
+func WriteReport(path string, results []Result) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	for _, result := range results {
+		if err := enc.Encode(result); err != nil {
+			return fmt.Errorf("failed to write report: %w", err)
+		}
+	}
+	return w.Flush()
+}
+
+// Summarize counts successes/failures and prints a one-line summary plus
+// each failure, matching the pattern used by the bulk user commands.
+func Summarize(results []Result) error {
+	var succeeded, failed int
+	for _, r := range results {
+		if r.Success {
+			succeeded++
+		} else {
+			failed++
+		}
+	}
+
+	fmt.Printf("\nSummary: %d succeeded, %d failed\n", succeeded, failed)
+	for _, r := range results {
+		if !r.Success {
+			fmt.Printf("  - %v: %s\n", r.Item, r.Error)
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d operations failed", failed, len(results))
+	}
+	return nil
+}
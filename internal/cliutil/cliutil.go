@@ -0,0 +1,51 @@
+// Package cliutil provides the shared Fatal helper used by Run (as opposed
+// to RunE) commands to report a failure and stop the process, since Run has
+// no return value cobra can use to print the error itself.
+package cliutil
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/rediacc/cli/internal/format"
+	"github.com/rediacc/cli/internal/utils"
+	"github.com/rediacc/cli/internal/validate"
+)
+
+// Exit codes returned by Fatal. Callers that need to script around CLI
+// failures (CI, shell pipelines) can rely on these to tell a malformed
+// invocation apart from a failure that happened while talking to the server.
+const (
+	ExitRuntimeError = 1
+	ExitUsageError   = 2
+	ExitAPIError     = 3
+)
+
+// Fatal prints err, if non-nil, and exits the process with an exit code
+// appropriate to its kind. The root command runs with SilenceUsage and
+// SilenceErrors, so this is the only place a Run function's error is
+// reported; call it as the last step of a Run function instead of
+// `return err`.
+func Fatal(cmd *cobra.Command, err error) {
+	if err == nil {
+		return
+	}
+	cmd.SilenceUsage = true
+	format.PrintErrorObject(err)
+	os.Exit(exitCodeFor(err))
+}
+
+// exitCodeFor maps an error to an exit code: malformed input (a
+// *utils.ValidationError, *utils.MultiError, or validate.FieldErrors) exits
+// 2, a failure reported by the middleware (*utils.APIError) exits 3, and
+// everything else exits 1.
+func exitCodeFor(err error) int {
+	switch err.(type) {
+	case *utils.ValidationError, *utils.MultiError, validate.FieldErrors:
+		return ExitUsageError
+	case *utils.APIError:
+		return ExitAPIError
+	default:
+		return ExitRuntimeError
+	}
+}
@@ -0,0 +1,142 @@
+// Package completion provides cached, API-backed values for cobra
+// ValidArgsFunction shell completion (team names, user emails), so pressing
+// Tab doesn't call the middleware on every keystroke.
+package completion
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rediacc/cli/internal/api"
+)
+
+// DefaultTTL is how long cached completion values are trusted before a
+// fresh API call is made.
+const DefaultTTL = 5 * time.Minute
+
+type cacheFile struct {
+	Teams        cacheEntry `json:"teams"`
+	UserEmails   cacheEntry `json:"user_emails"`
+}
+
+type cacheEntry struct {
+	FetchedAt time.Time `json:"fetched_at"`
+	Values    []string  `json:"values"`
+}
+
+// cachePath returns $XDG_CACHE_HOME/rediacc/completion.json, falling back to
+// ~/.cache/rediacc/completion.json when XDG_CACHE_HOME is unset.
+func cachePath() (string, error) {
+	base := os.Getenv("XDG_CACHE_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("could not determine cache directory: %w", err)
+		}
+		base = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(base, "rediacc", "completion.json"), nil
+}
+
+func readCache() cacheFile {
+	path, err := cachePath()
+	if err != nil {
+		return cacheFile{}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cacheFile{}
+	}
+
+	var cache cacheFile
+	_ = json.Unmarshal(data, &cache)
+	return cache
+}
+
+func writeCache(cache cacheFile) error {
+	path, err := cachePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		return fmt.Errorf("failed to marshal completion cache: %w", err)
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// Teams returns team names, refreshing from GetCompanyTeams when the cached
+// entry is older than ttl.
+func Teams(ctx context.Context, ttl time.Duration) []string {
+	return cached(ctx, ttl, func(c cacheFile) cacheEntry { return c.Teams },
+		func(c *cacheFile, e cacheEntry) { c.Teams = e },
+		func(client *api.Client) ([]string, error) {
+			response, err := client.ExecuteStoredProcedure("GetCompanyTeams", map[string]interface{}{})
+			if err != nil {
+				return nil, err
+			}
+			names := make([]string, 0, len(response.Data))
+			for _, row := range response.Data {
+				if name, ok := row["name"].(string); ok {
+					names = append(names, name)
+				}
+			}
+			return names, nil
+		})
+}
+
+// UserEmails returns company user emails, refreshing from GetAllCompanyUsers
+// when the cached entry is older than ttl.
+func UserEmails(ctx context.Context, ttl time.Duration) []string {
+	return cached(ctx, ttl, func(c cacheFile) cacheEntry { return c.UserEmails },
+		func(c *cacheFile, e cacheEntry) { c.UserEmails = e },
+		func(client *api.Client) ([]string, error) {
+			response, err := client.ExecuteStoredProcedure("GetAllCompanyUsers", map[string]interface{}{})
+			if err != nil {
+				return nil, err
+			}
+			emails := make([]string, 0, len(response.Data))
+			for _, row := range response.Data {
+				if email, ok := row["email"].(string); ok {
+					emails = append(emails, email)
+				}
+			}
+			return emails, nil
+		})
+}
+
+// cached implements the shared get-from-cache-or-refresh logic for Teams and
+// UserEmails. Completion failures are swallowed (return nil) since cobra
+// completion has no good way to surface an error to the shell.
+func cached(ctx context.Context, ttl time.Duration, get func(cacheFile) cacheEntry, set func(*cacheFile, cacheEntry), fetch func(*api.Client) ([]string, error)) []string {
+	cache := readCache()
+	entry := get(cache)
+	if time.Since(entry.FetchedAt) < ttl && len(entry.Values) > 0 {
+		return entry.Values
+	}
+
+	client, err := api.NewAuthenticatedClient(ctx)
+	if err != nil {
+		// Serve stale data rather than nothing if we can't load a session.
+		return entry.Values
+	}
+
+	values, err := fetch(client)
+	if err != nil || values == nil {
+		// Serve stale data rather than nothing if the refresh failed.
+		return entry.Values
+	}
+
+	set(&cache, cacheEntry{FetchedAt: time.Now(), Values: values})
+	_ = writeCache(cache)
+	return values
+}
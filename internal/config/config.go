@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
@@ -16,6 +17,37 @@ type Config struct {
 	Jobs   JobsConfig   `yaml:"jobs"`
 	Format FormatConfig `yaml:"format"`
 	SSH    SSHConfig    `yaml:"ssh"`
+	Vault  VaultConfig  `yaml:"vault"`
+
+	// Credentials selects which credstore.Store backs session credentials;
+	// see CredentialsBackend.
+	Credentials CredentialsConfig `yaml:"credentials"`
+
+	// Retry, RateLimit, and Breaker configure the api.Client HTTP transport
+	// pipeline: retry-with-backoff, per-host rate limiting, and circuit
+	// breaking, in that order from innermost to outermost.
+	Retry     RetryConfig     `yaml:"retry"`
+	RateLimit RateLimitConfig `yaml:"ratelimit"`
+	Breaker   BreakerConfig   `yaml:"breaker"`
+
+	// ActiveProfile is the persisted profile selected by `config use`. It is
+	// overridden for a single invocation by the --profile flag or the
+	// REDIACC_PROFILE environment variable; see Initialize.
+	ActiveProfile string                   `yaml:"active_profile,omitempty"`
+	Profiles      map[string]ProfileConfig `yaml:"profiles,omitempty"`
+}
+
+// ProfileConfig holds the settings for one named environment (e.g. dev,
+// staging, prod) under the top-level `profiles:` map. Any field left at its
+// zero value falls back to the base Config loaded from the rest of the
+// file, so a profile only needs to specify what differs from it.
+type ProfileConfig struct {
+	Server ServerConfig `yaml:"server"`
+	Auth   AuthConfig   `yaml:"auth"`
+	Jobs   JobsConfig   `yaml:"jobs"`
+	Format FormatConfig `yaml:"format"`
+	SSH    SSHConfig    `yaml:"ssh"`
+	Vault  VaultConfig  `yaml:"vault"`
 }
 
 // ServerConfig contains server connection settings
@@ -29,6 +61,13 @@ type AuthConfig struct {
 	Email             string `yaml:"email"`
 	SessionToken      string `yaml:"session_token"`
 	RequestCredential string `yaml:"request_credential"`
+
+	// ClientCertFile/ClientKeyFile/CAFile back the mTLS auth mode set up by
+	// `auth cert-enroll`/`auth cert-login`: a signed client certificate used
+	// instead of (or alongside) RequestCredential. See api.NewClientWithCert.
+	ClientCertFile string `yaml:"client_cert_file"`
+	ClientKeyFile  string `yaml:"client_key_file"`
+	CAFile         string `yaml:"ca_file"`
 }
 
 // JobsConfig contains job execution settings
@@ -61,16 +100,101 @@ type SSHConfig struct {
 	RetryDelay    string `yaml:"retry_delay"`
 }
 
+// CredentialsConfig selects the credstore.Store backend that holds the
+// session credential rotated by `auth login`/`auth refresh`.
+type CredentialsConfig struct {
+	// Backend is one of "keyring" (default), "file", or "env"; see
+	// credstore.Select. Overridden per invocation by --credentials-backend.
+	Backend string `yaml:"backend"`
+}
+
+// RetryConfig controls the retry-with-backoff transport every api.Client
+// request goes through: up to MaxAttempts retries of 5xx/429/connection
+// errors, with exponential backoff starting at InitialBackoff.
+type RetryConfig struct {
+	MaxAttempts    int    `yaml:"max_attempts"`
+	InitialBackoff string `yaml:"initial_backoff"`
+}
+
+// RateLimitConfig controls the per-host token-bucket rate limiter transport.
+// RPS of 0 (the default) disables rate limiting.
+type RateLimitConfig struct {
+	RPS float64 `yaml:"rps"`
+}
+
+// BreakerConfig controls the circuit breaker transport that short-circuits
+// requests to a host after Threshold consecutive failures. Threshold of 0
+// (the default) disables the breaker.
+type BreakerConfig struct {
+	Threshold int `yaml:"threshold"`
+}
+
+// VaultConfig contains settings for the encrypted local vault cache used by
+// `company vault edit`.
+type VaultConfig struct {
+	// Identity is the path to an age identity file. When set, the vault
+	// cache is encrypted to its public key(s) instead of a passphrase.
+	Identity string `yaml:"identity"`
+}
+
 var (
 	globalConfig *Config
 	debug        bool
+	verbose      bool
 	outputFormat string
+	noHeaders    bool
+	fields       []string
+	columns      []string
+	query        string
+
+	// profileOverride is set from the --profile flag, before Initialize
+	// runs, so it can take part in resolving which profile applies.
+	profileOverride string
+	// activeProfileName is the profile that was actually applied, for
+	// introspection (e.g. `config list`, `config profile list`).
+	activeProfileName string
+
+	// credentialsBackendOverride is set from the --credentials-backend
+	// flag; see CredentialsBackend.
+	credentialsBackendOverride string
+	// resolvedConfigPath is the config file Initialize actually loaded,
+	// reused by Save so it writes back to the same file even when --config
+	// pointed somewhere other than the default ~/.rediacc-cli.yaml.
+	resolvedConfigPath string
 )
 
+// SetProfileOverride records the --profile flag value. It must be called
+// before Initialize, since the resolved profile affects which Server/Auth/...
+// values Initialize loads into the global config.
+func SetProfileOverride(name string) {
+	profileOverride = name
+}
+
+// SetCredentialsBackendOverride records the --credentials-backend flag
+// value, which takes precedence over the persisted `credentials.backend`
+// config key; see CredentialsBackend.
+func SetCredentialsBackendOverride(name string) {
+	credentialsBackendOverride = name
+}
+
+// CredentialsBackend resolves which credstore.Store backend to use:
+// --credentials-backend, then the persisted `credentials.backend` key,
+// then "keyring".
+func CredentialsBackend() string {
+	if credentialsBackendOverride != "" {
+		return credentialsBackendOverride
+	}
+	if globalConfig != nil && globalConfig.Credentials.Backend != "" {
+		return globalConfig.Credentials.Backend
+	}
+	return "keyring"
+}
+
 // Initialize loads the configuration from file
 func Initialize(configFile string) error {
 	if configFile != "" {
 		viper.SetConfigFile(configFile)
+		resolvedConfigPath = configFile
 	} else {
 		home, err := os.UserHomeDir()
 		if err != nil {
@@ -80,6 +204,7 @@ func Initialize(configFile string) error {
 		viper.AddConfigPath(home)
 		viper.SetConfigType("yaml")
 		viper.SetConfigName(".rediacc-cli")
+		resolvedConfigPath = filepath.Join(home, ".rediacc-cli.yaml")
 	}
 
 	// Set defaults
@@ -107,10 +232,123 @@ func Initialize(configFile string) error {
 	globalConfig.Auth.Email = viper.GetString("auth.email")
 	globalConfig.Auth.SessionToken = viper.GetString("auth.session_token")
 	globalConfig.Auth.RequestCredential = viper.GetString("auth.request_credential")
+	globalConfig.Auth.ClientCertFile = viper.GetString("auth.client_cert_file")
+	globalConfig.Auth.ClientKeyFile = viper.GetString("auth.client_key_file")
+	globalConfig.Auth.CAFile = viper.GetString("auth.ca_file")
+	globalConfig.Credentials.Backend = viper.GetString("credentials.backend")
+	globalConfig.Retry.MaxAttempts = viper.GetInt("retry.max_attempts")
+	globalConfig.Retry.InitialBackoff = viper.GetString("retry.initial_backoff")
+	globalConfig.RateLimit.RPS = viper.GetFloat64("ratelimit.rps")
+	globalConfig.Breaker.Threshold = viper.GetInt("breaker.threshold")
+
+	// viper's default field matching (no mapstructure tags) misses
+	// active_profile/profiles for the same reason as auth above, so read
+	// them straight off disk with the yaml tags that are actually on Config.
+	activeProfile, profiles, err := readProfilesFromDisk(resolvedConfigPath)
+	if err != nil {
+		return fmt.Errorf("could not read profiles: %w", err)
+	}
+	globalConfig.ActiveProfile = activeProfile
+	globalConfig.Profiles = profiles
+
+	// Precedence: --profile flag > REDIACC_PROFILE env var > persisted
+	// `config use` selection > base config (no profile).
+	activeProfileName = profileOverride
+	if activeProfileName == "" {
+		activeProfileName = os.Getenv("REDIACC_PROFILE")
+	}
+	if activeProfileName == "" {
+		activeProfileName = globalConfig.ActiveProfile
+	}
+
+	if activeProfileName != "" {
+		profile, ok := globalConfig.Profiles[activeProfileName]
+		if !ok {
+			return fmt.Errorf("profile %q not found (see `config profile list`)", activeProfileName)
+		}
+		applyProfile(globalConfig, profile)
+	}
 
 	return nil
 }
 
+// readProfilesFromDisk reads active_profile/profiles directly from the
+// config file via yaml.Unmarshal, bypassing viper's default field matching.
+func readProfilesFromDisk(path string) (string, map[string]ProfileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil, nil
+		}
+		return "", nil, err
+	}
+
+	var doc struct {
+		ActiveProfile string                   `yaml:"active_profile"`
+		Profiles      map[string]ProfileConfig `yaml:"profiles"`
+	}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return "", nil, err
+	}
+	return doc.ActiveProfile, doc.Profiles, nil
+}
+
+// applyProfile overlays any non-zero field of p onto cfg. Fields a profile
+// doesn't set keep whatever the base config (or defaults) already loaded.
+func applyProfile(cfg *Config, p ProfileConfig) {
+	if p.Server.URL != "" {
+		cfg.Server.URL = p.Server.URL
+	}
+	if p.Server.Timeout != "" {
+		cfg.Server.Timeout = p.Server.Timeout
+	}
+	if p.Auth.Email != "" {
+		cfg.Auth.Email = p.Auth.Email
+	}
+	if p.Auth.SessionToken != "" {
+		cfg.Auth.SessionToken = p.Auth.SessionToken
+	}
+	if p.Auth.RequestCredential != "" {
+		cfg.Auth.RequestCredential = p.Auth.RequestCredential
+	}
+	if p.Auth.ClientCertFile != "" {
+		cfg.Auth.ClientCertFile = p.Auth.ClientCertFile
+	}
+	if p.Auth.ClientKeyFile != "" {
+		cfg.Auth.ClientKeyFile = p.Auth.ClientKeyFile
+	}
+	if p.Auth.CAFile != "" {
+		cfg.Auth.CAFile = p.Auth.CAFile
+	}
+	if p.Jobs.DefaultDatastoreSize != "" {
+		cfg.Jobs.DefaultDatastoreSize = p.Jobs.DefaultDatastoreSize
+	}
+	if p.Jobs.SSHTimeout != "" {
+		cfg.Jobs.SSHTimeout = p.Jobs.SSHTimeout
+	}
+	if p.Jobs.SSHKeyPath != "" {
+		cfg.Jobs.SSHKeyPath = p.Jobs.SSHKeyPath
+	}
+	if len(p.Jobs.Machines) > 0 {
+		cfg.Jobs.Machines = p.Jobs.Machines
+	}
+	if p.Format.Default != "" {
+		cfg.Format.Default = p.Format.Default
+	}
+	if p.SSH.Timeout != "" {
+		cfg.SSH.Timeout = p.SSH.Timeout
+	}
+	if p.SSH.RetryAttempts != 0 {
+		cfg.SSH.RetryAttempts = p.SSH.RetryAttempts
+	}
+	if p.SSH.RetryDelay != "" {
+		cfg.SSH.RetryDelay = p.SSH.RetryDelay
+	}
+	if p.Vault.Identity != "" {
+		cfg.Vault.Identity = p.Vault.Identity
+	}
+}
+
 // setDefaults sets default configuration values
 func setDefaults() {
 	viper.SetDefault("server.url", "http://localhost:8080")
@@ -118,6 +356,9 @@ func setDefaults() {
 	viper.SetDefault("auth.email", "")
 	viper.SetDefault("auth.session_token", "")
 	viper.SetDefault("auth.request_credential", "")
+	viper.SetDefault("auth.client_cert_file", "")
+	viper.SetDefault("auth.client_key_file", "")
+	viper.SetDefault("auth.ca_file", "")
 	viper.SetDefault("jobs.default_datastore_size", "100G")
 	viper.SetDefault("jobs.ssh_timeout", "30s")
 	viper.SetDefault("jobs.ssh_key_path", "~/.ssh/id_rsa")
@@ -127,6 +368,12 @@ func setDefaults() {
 	viper.SetDefault("ssh.timeout", "30s")
 	viper.SetDefault("ssh.retry_attempts", 3)
 	viper.SetDefault("ssh.retry_delay", "5s")
+	viper.SetDefault("vault.identity", "")
+	viper.SetDefault("credentials.backend", "keyring")
+	viper.SetDefault("retry.max_attempts", 3)
+	viper.SetDefault("retry.initial_backoff", "500ms")
+	viper.SetDefault("ratelimit.rps", 0)
+	viper.SetDefault("breaker.threshold", 0)
 }
 
 // createDefaultConfig creates a default configuration file
@@ -184,13 +431,15 @@ func Save() error {
 		return fmt.Errorf("no configuration to save")
 	}
 
-	home, err := os.UserHomeDir()
-	if err != nil {
-		return err
+	configPath := resolvedConfigPath
+	if configPath == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		configPath = filepath.Join(home, ".rediacc-cli.yaml")
 	}
 
-	configPath := filepath.Join(home, ".rediacc-cli.yaml")
-
 	data, err := yaml.Marshal(globalConfig)
 	if err != nil {
 		return err
@@ -209,6 +458,18 @@ func IsDebug() bool {
 	return debug
 }
 
+// SetVerbose sets whether the api.Client request-logging transport prints
+// each request/response to stderr.
+func SetVerbose(enabled bool) {
+	verbose = enabled
+}
+
+// IsVerbose returns whether the api.Client request-logging transport is
+// enabled.
+func IsVerbose() bool {
+	return verbose
+}
+
 // SetOutputFormat sets the output format
 func SetOutputFormat(format string) {
 	outputFormat = format
@@ -225,39 +486,195 @@ func GetOutputFormat() string {
 	return "table"
 }
 
-// UpdateAuth updates the authentication configuration
+// SetNoHeaders sets whether tabular formatters should omit header rows,
+// for scripting use cases.
+func SetNoHeaders(enabled bool) {
+	noHeaders = enabled
+}
+
+// NoHeaders returns whether tabular formatters should omit header rows.
+func NoHeaders() bool {
+	return noHeaders
+}
+
+// SetFields sets the ordered list of fields to project before formatting,
+// e.g. from a --fields email,status,last_login_at flag.
+func SetFields(f []string) {
+	fields = f
+}
+
+// Fields returns the configured field projection, or nil if none was set.
+func Fields() []string {
+	return fields
+}
+
+// SetColumns sets the ordered list of columns the csv/tsv formatters should
+// emit, from --columns.
+func SetColumns(c []string) {
+	columns = c
+}
+
+// Columns returns the configured csv/tsv column list, or nil if none was set.
+func Columns() []string {
+	return columns
+}
+
+// SetQuery sets the --query/--jq expression applied to response data before
+// it reaches the chosen --output formatter.
+func SetQuery(q string) {
+	query = q
+}
+
+// Query returns the configured --query/--jq expression, or "" if none was set.
+func Query() string {
+	return query
+}
+
+// ActiveProfileName returns the profile that was actually applied this run
+// (after --profile/REDIACC_PROFILE/`config use` precedence), or "" if none.
+func ActiveProfileName() string {
+	return activeProfileName
+}
+
+// ListProfiles returns the configured profile names in sorted order.
+func ListProfiles() []string {
+	cfg := Get()
+	names := make([]string, 0, len(cfg.Profiles))
+	for name := range cfg.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UseProfile sets name as the persisted active profile (`config use <name>`).
+// It does not change the running process's in-memory config; the new
+// profile takes effect on the next invocation.
+func UseProfile(name string) error {
+	cfg := Get()
+	if _, ok := cfg.Profiles[name]; !ok {
+		return fmt.Errorf("profile %q not found (see `config profile list`)", name)
+	}
+	cfg.ActiveProfile = name
+	return Save()
+}
+
+// CreateProfile adds or overwrites a named profile with the given settings.
+func CreateProfile(name string, profile ProfileConfig) error {
+	if name == "" {
+		return fmt.Errorf("profile name is required")
+	}
+	cfg := Get()
+	if cfg.Profiles == nil {
+		cfg.Profiles = make(map[string]ProfileConfig)
+	}
+	cfg.Profiles[name] = profile
+	return Save()
+}
+
+// DeleteProfile removes a named profile, clearing ActiveProfile if it was
+// the profile currently selected by `config use`.
+func DeleteProfile(name string) error {
+	cfg := Get()
+	if _, ok := cfg.Profiles[name]; !ok {
+		return fmt.Errorf("profile %q not found", name)
+	}
+	delete(cfg.Profiles, name)
+	if cfg.ActiveProfile == name {
+		cfg.ActiveProfile = ""
+	}
+	return Save()
+}
+
+// authKeyPrefix returns the viper key prefix auth fields should be written
+// under: "auth" with no active profile, or "profiles.<name>.auth" when one
+// is active, so logging in while on a profile updates that profile's own
+// auth block instead of clobbering the base config's.
+func authKeyPrefix() string {
+	if activeProfileName == "" {
+		return "auth"
+	}
+	return fmt.Sprintf("profiles.%s.auth", activeProfileName)
+}
+
+// authConfig returns the AuthConfig that backs the active profile (or the
+// base config, if none is active) and a setter to write it back.
+func authConfig() (AuthConfig, func(AuthConfig)) {
+	if activeProfileName == "" {
+		return globalConfig.Auth, func(a AuthConfig) { globalConfig.Auth = a }
+	}
+	if globalConfig.Profiles == nil {
+		globalConfig.Profiles = make(map[string]ProfileConfig)
+	}
+	profile := globalConfig.Profiles[activeProfileName]
+	return profile.Auth, func(a AuthConfig) {
+		profile.Auth = a
+		globalConfig.Profiles[activeProfileName] = profile
+	}
+}
+
+// UpdateAuth updates the authentication configuration, writing to the
+// active profile's auth block if one is selected (see authKeyPrefix).
 func UpdateAuth(email, sessionToken, requestCredential string) error {
 	if globalConfig == nil {
 		return fmt.Errorf("configuration not initialized")
 	}
 
-	// Update both viper and globalConfig
-	viper.Set("auth.email", email)
-	viper.Set("auth.session_token", sessionToken)  
-	viper.Set("auth.request_credential", requestCredential)
-	
-	globalConfig.Auth.Email = email
-	globalConfig.Auth.SessionToken = sessionToken
-	globalConfig.Auth.RequestCredential = requestCredential
+	prefix := authKeyPrefix()
+	viper.Set(prefix+".email", email)
+	viper.Set(prefix+".session_token", sessionToken)
+	viper.Set(prefix+".request_credential", requestCredential)
+
+	auth, setAuth := authConfig()
+	auth.Email = email
+	auth.SessionToken = sessionToken
+	auth.RequestCredential = requestCredential
+	setAuth(auth)
 
 	// Save using viper to ensure consistency
 	return viper.WriteConfig()
 }
 
-// ClearAuth clears the authentication configuration
+// UpdateClientCert records the paths of a signed client certificate, its
+// private key, and (optionally) the CA bundle to verify the server with,
+// as set up by `auth cert-enroll`. Writes to the active profile's auth
+// block if one is selected (see authKeyPrefix).
+func UpdateClientCert(certFile, keyFile, caFile string) error {
+	if globalConfig == nil {
+		return fmt.Errorf("configuration not initialized")
+	}
+
+	prefix := authKeyPrefix()
+	viper.Set(prefix+".client_cert_file", certFile)
+	viper.Set(prefix+".client_key_file", keyFile)
+	viper.Set(prefix+".ca_file", caFile)
+
+	auth, setAuth := authConfig()
+	auth.ClientCertFile = certFile
+	auth.ClientKeyFile = keyFile
+	auth.CAFile = caFile
+	setAuth(auth)
+
+	return viper.WriteConfig()
+}
+
+// ClearAuth clears the authentication configuration, on the active
+// profile's auth block if one is selected (see authKeyPrefix).
 func ClearAuth() error {
 	if globalConfig == nil {
 		return fmt.Errorf("configuration not initialized")
 	}
 
-	// Clear both viper and globalConfig
-	viper.Set("auth.email", "")
-	viper.Set("auth.session_token", "")
-	viper.Set("auth.request_credential", "")
+	prefix := authKeyPrefix()
+	viper.Set(prefix+".email", "")
+	viper.Set(prefix+".session_token", "")
+	viper.Set(prefix+".request_credential", "")
 
-	globalConfig.Auth.Email = ""
-	globalConfig.Auth.SessionToken = ""
-	globalConfig.Auth.RequestCredential = ""
+	auth, setAuth := authConfig()
+	auth.Email = ""
+	auth.SessionToken = ""
+	auth.RequestCredential = ""
+	setAuth(auth)
 
 	return viper.WriteConfig()
 }
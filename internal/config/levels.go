@@ -0,0 +1,87 @@
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rediacc/cli/internal/credstore"
+)
+
+// Initialize unmarshals every section of the config file up front (viper
+// doesn't offer a cheaper partial read), so LoadMinimal/LoadServer/LoadFull
+// below are validators, not loaders: each asserts that the section(s) a
+// command actually touches are present and well-formed, without requiring
+// sections it doesn't. A command that only needs the API client, like
+// `permissions groups list`, calls LoadMinimal so a misconfigured
+// jobs/ssh section it never reads doesn't block it; Initialize used to
+// silently default those missing keys regardless, which hid the
+// misconfiguration a command actually cared about.
+
+// LoadMinimal validates that the config has what any authenticated API call
+// needs: a non-empty Server.URL and a session (Auth.Email plus either a
+// session credential or an mTLS client certificate). The session credential
+// itself normally lives in the credstore.Store backend (see
+// CredentialsBackend), not the plaintext config file, since Login zero-fills
+// Auth.RequestCredential there once it persists the real session; a
+// non-empty Auth.RequestCredential is only ever seen on installs that
+// predate the credstore and haven't logged in again since.
+func LoadMinimal() error {
+	cfg := Get()
+	if cfg.Server.URL == "" {
+		return fmt.Errorf("server.url is not configured (see `rediacc config set server.url <url>` or --config)")
+	}
+	if cfg.Auth.Email == "" {
+		return fmt.Errorf("not logged in (see `rediacc auth login`)")
+	}
+	hasCert := cfg.Auth.ClientCertFile != "" && cfg.Auth.ClientKeyFile != ""
+	if hasCert || cfg.Auth.RequestCredential != "" {
+		return nil
+	}
+	if _, err := credstore.Select(CredentialsBackend()).Get(cfg.Server.URL, cfg.Auth.Email); err != nil {
+		return fmt.Errorf("no session credential or client certificate found for %s (see `rediacc auth login`)", cfg.Auth.Email)
+	}
+	return nil
+}
+
+// LoadServer additionally validates the Server section itself is
+// well-formed, for commands that read it beyond building the default
+// client (e.g. ones that print Server.URL or rely on Server.Timeout).
+func LoadServer() error {
+	if err := LoadMinimal(); err != nil {
+		return err
+	}
+	if _, err := parseServerTimeout(); err != nil {
+		return fmt.Errorf("server.timeout: %w", err)
+	}
+	return nil
+}
+
+// LoadFull additionally validates the Jobs/SSH sections, for commands
+// (machine exec, file push/pull, terminal) that actually read them.
+func LoadFull() error {
+	if err := LoadServer(); err != nil {
+		return err
+	}
+	cfg := Get()
+	if _, err := parseDuration(cfg.Jobs.SSHTimeout); err != nil {
+		return fmt.Errorf("jobs.ssh_timeout: %w", err)
+	}
+	if _, err := parseDuration(cfg.SSH.Timeout); err != nil {
+		return fmt.Errorf("ssh.timeout: %w", err)
+	}
+	return nil
+}
+
+// parseServerTimeout parses the active Server.Timeout as a duration.
+func parseServerTimeout() (time.Duration, error) {
+	return parseDuration(Get().Server.Timeout)
+}
+
+// parseDuration parses s as a time.Duration, reporting an empty string as
+// its own error rather than the unhelpful "time: invalid duration \"\"".
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, fmt.Errorf("not set")
+	}
+	return time.ParseDuration(s)
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// DiffProfiles compares two named profiles field-by-field and returns the
+// dotted-path fields that differ, formatted as "path: a -> b". Either name
+// may be "" to mean the base config (the settings in effect with no
+// profile selected).
+func DiffProfiles(a, b string) ([]string, error) {
+	pa, err := resolveProfile(a)
+	if err != nil {
+		return nil, err
+	}
+	pb, err := resolveProfile(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var diffs []string
+	diffStructs("", reflect.ValueOf(pa), reflect.ValueOf(pb), &diffs)
+	return diffs, nil
+}
+
+// CopyProfile duplicates an existing profile's settings under a new name,
+// overwriting dst if it already exists.
+func CopyProfile(src, dst string) error {
+	cfg := Get()
+	profile, ok := cfg.Profiles[src]
+	if !ok {
+		return fmt.Errorf("profile %q not found", src)
+	}
+	return CreateProfile(dst, profile)
+}
+
+// resolveProfile looks up a named profile, or the base config's settings
+// (reshaped as a ProfileConfig) when name is "".
+func resolveProfile(name string) (ProfileConfig, error) {
+	cfg := Get()
+	if name == "" {
+		return ProfileConfig{
+			Server: cfg.Server,
+			Auth:   cfg.Auth,
+			Jobs:   cfg.Jobs,
+			Format: cfg.Format,
+			SSH:    cfg.SSH,
+			Vault:  cfg.Vault,
+		}, nil
+	}
+	profile, ok := cfg.Profiles[name]
+	if !ok {
+		return ProfileConfig{}, fmt.Errorf("profile %q not found", name)
+	}
+	return profile, nil
+}
+
+// diffStructs walks two struct values of identical type field by field,
+// recursing into nested structs and building dotted paths from each field's
+// yaml tag, appending "path: a -> b" to out wherever the leaf values differ.
+func diffStructs(prefix string, a, b reflect.Value, out *[]string) {
+	t := a.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "" {
+			name = field.Name
+		}
+		path := name
+		if prefix != "" {
+			path = prefix + "." + name
+		}
+
+		fa, fb := a.Field(i), b.Field(i)
+		if fa.Kind() == reflect.Struct {
+			diffStructs(path, fa, fb, out)
+			continue
+		}
+		if !reflect.DeepEqual(fa.Interface(), fb.Interface()) {
+			*out = append(*out, fmt.Sprintf("%s: %v -> %v", path, fa.Interface(), fb.Interface()))
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package credstore
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Environment variables envStore reads the session credential from. They
+// are not scoped per server+email: CI runs authenticate as a single
+// identity, injected by whatever job or secret manager invoked `rediacc`.
+const (
+	envSessionToken      = "REDIACC_SESSION_TOKEN"
+	envRequestCredential = "REDIACC_REQUEST_CREDENTIAL"
+)
+
+// envStore reads the session credential from the environment, falling
+// back to a single line on stdin, for CI where nothing should be written
+// to disk. Set and Delete are no-ops: the credential is owned by whatever
+// injected it (a secret manager, a pipeline variable), not by this store.
+type envStore struct{}
+
+func newEnvStore() *envStore {
+	return &envStore{}
+}
+
+func (*envStore) Name() string { return BackendEnv }
+
+func (*envStore) Get(serverURL, email string) (*Credential, error) {
+	requestCredential := os.Getenv(envRequestCredential)
+	sessionToken := os.Getenv(envSessionToken)
+
+	if requestCredential == "" {
+		line, err := readStdinLine()
+		if err != nil {
+			return nil, fmt.Errorf("%s is not set and stdin has no credential: %w", envRequestCredential, err)
+		}
+		requestCredential = line
+	}
+	if sessionToken == "" {
+		sessionToken = requestCredential
+	}
+
+	return &Credential{SessionToken: sessionToken, RequestCredential: requestCredential}, nil
+}
+
+func (*envStore) Set(serverURL, email string, cred Credential) error {
+	return nil
+}
+
+func (*envStore) Delete(serverURL, email string) error {
+	return nil
+}
+
+// readStdinLine reads a single trimmed line from stdin, for a pipeline
+// that pipes in a credential rather than exporting it as a named
+// environment variable.
+func readStdinLine() (string, error) {
+	scanner := bufio.NewScanner(os.Stdin)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("stdin is empty")
+	}
+	line := strings.TrimSpace(scanner.Text())
+	if line == "" {
+		return "", fmt.Errorf("stdin is empty")
+	}
+	return line, nil
+}
@@ -0,0 +1,47 @@
+package credstore
+
+import "testing"
+
+func TestEnvStoreGetUsesEnvVars(t *testing.T) {
+	t.Setenv(envRequestCredential, "req-cred")
+	t.Setenv(envSessionToken, "session-tok")
+
+	store := newEnvStore()
+	cred, err := store.Get("https://example.com", "user@example.com")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if cred.RequestCredential != "req-cred" || cred.SessionToken != "session-tok" {
+		t.Fatalf("got %+v, want RequestCredential=req-cred SessionToken=session-tok", cred)
+	}
+}
+
+func TestEnvStoreGetFallsBackSessionTokenToRequestCredential(t *testing.T) {
+	t.Setenv(envRequestCredential, "req-cred")
+	t.Setenv(envSessionToken, "")
+
+	store := newEnvStore()
+	cred, err := store.Get("https://example.com", "user@example.com")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if cred.SessionToken != "req-cred" {
+		t.Fatalf("SessionToken = %q, want it to fall back to RequestCredential", cred.SessionToken)
+	}
+}
+
+func TestEnvStoreSetAndDeleteAreNoOps(t *testing.T) {
+	store := newEnvStore()
+	if err := store.Set("https://example.com", "user@example.com", Credential{RequestCredential: "x"}); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := store.Delete("https://example.com", "user@example.com"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+}
+
+func TestEnvStoreName(t *testing.T) {
+	if got := newEnvStore().Name(); got != BackendEnv {
+		t.Fatalf("Name() = %q, want %q", got, BackendEnv)
+	}
+}
@@ -0,0 +1,117 @@
+package credstore
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gofrs/flock"
+)
+
+// fileStore persists credentials as mode-0600 JSON files under
+// ~/.rediacc-cli/credentials/, one per server+email pair. It is the
+// default fallback when no keyring backend is available, and the explicit
+// choice for BackendFile.
+type fileStore struct{}
+
+func newFileStore() *fileStore {
+	return &fileStore{}
+}
+
+func (*fileStore) Name() string { return BackendFile }
+
+func (f *fileStore) Get(serverURL, email string) (*Credential, error) {
+	path, err := f.path(serverURL, email)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, errNotFound(email)
+		}
+		return nil, fmt.Errorf("failed to read credential file: %w", err)
+	}
+
+	var cred Credential
+	if err := json.Unmarshal(data, &cred); err != nil {
+		return nil, fmt.Errorf("failed to parse stored credential: %w", err)
+	}
+	return &cred, nil
+}
+
+func (f *fileStore) Set(serverURL, email string, cred Credential) error {
+	path, err := f.path(serverURL, email)
+	if err != nil {
+		return err
+	}
+
+	payload, err := json.Marshal(cred)
+	if err != nil {
+		return fmt.Errorf("failed to marshal credential: %w", err)
+	}
+
+	return withCredentialLock(func() error {
+		if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+			return fmt.Errorf("failed to create credential directory: %w", err)
+		}
+		return os.WriteFile(path, payload, 0600)
+	})
+}
+
+func (f *fileStore) Delete(serverURL, email string) error {
+	path, err := f.path(serverURL, email)
+	if err != nil {
+		return err
+	}
+
+	return withCredentialLock(func() error {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove credential file: %w", err)
+		}
+		return nil
+	})
+}
+
+// path returns the on-disk location for a server+email pair's credential.
+func (f *fileStore) path(serverURL, email string) (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not get home directory: %w", err)
+	}
+	return filepath.Join(home, ".rediacc-cli", "credentials", credentialKey(serverURL, email)+".json"), nil
+}
+
+// credentialKey identifies a credential by server URL and email, since a
+// single user may have sessions against multiple Rediacc servers.
+func credentialKey(serverURL, email string) string {
+	sum := sha256.Sum256([]byte(serverURL))
+	return fmt.Sprintf("%x:%s", sum[:8], email)
+}
+
+// withCredentialLock runs fn while holding an exclusive, cross-process file
+// lock over the credential store, so a proactive background refresh in one
+// process can't interleave with a rotation from another (e.g. two `rediacc`
+// invocations sharing the same ~/.rediacc-cli/credentials directory).
+func withCredentialLock(fn func() error) error {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("could not get home directory: %w", err)
+	}
+
+	dir := filepath.Join(home, ".rediacc-cli", "credentials")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return fmt.Errorf("failed to create credential directory: %w", err)
+	}
+
+	lock := flock.New(filepath.Join(dir, ".lock"))
+	if err := lock.Lock(); err != nil {
+		return fmt.Errorf("failed to acquire credential lock: %w", err)
+	}
+	defer lock.Unlock()
+
+	return fn()
+}
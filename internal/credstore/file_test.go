@@ -0,0 +1,67 @@
+package credstore
+
+import "testing"
+
+func TestFileStoreSetGetDelete(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	store := newFileStore()
+	serverURL, email := "https://example.com", "user@example.com"
+
+	if _, err := store.Get(serverURL, email); err == nil {
+		t.Fatalf("Get before Set = nil error, want errNotFound")
+	}
+
+	want := Credential{SessionToken: "tok", RequestCredential: "req"}
+	if err := store.Set(serverURL, email, want); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	got, err := store.Get(serverURL, email)
+	if err != nil {
+		t.Fatalf("Get after Set: %v", err)
+	}
+	if *got != want {
+		t.Fatalf("got %+v, want %+v", *got, want)
+	}
+
+	if err := store.Delete(serverURL, email); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(serverURL, email); err == nil {
+		t.Fatalf("Get after Delete = nil error, want errNotFound")
+	}
+}
+
+func TestFileStoreDeleteMissingIsNoOp(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	store := newFileStore()
+	if err := store.Delete("https://example.com", "nobody@example.com"); err != nil {
+		t.Fatalf("Delete on missing credential = %v, want nil", err)
+	}
+}
+
+func TestFileStoreKeysSeparateServersAndEmails(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	store := newFileStore()
+	if err := store.Set("https://a.example.com", "user@example.com", Credential{RequestCredential: "a"}); err != nil {
+		t.Fatalf("Set a: %v", err)
+	}
+	if err := store.Set("https://b.example.com", "user@example.com", Credential{RequestCredential: "b"}); err != nil {
+		t.Fatalf("Set b: %v", err)
+	}
+
+	gotA, err := store.Get("https://a.example.com", "user@example.com")
+	if err != nil {
+		t.Fatalf("Get a: %v", err)
+	}
+	gotB, err := store.Get("https://b.example.com", "user@example.com")
+	if err != nil {
+		t.Fatalf("Get b: %v", err)
+	}
+	if gotA.RequestCredential != "a" || gotB.RequestCredential != "b" {
+		t.Fatalf("credentials bled across servers: a=%+v b=%+v", gotA, gotB)
+	}
+}
@@ -0,0 +1,60 @@
+package credstore
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/zalando/go-keyring"
+)
+
+// keyringService is the OS keyring service name under which session
+// credentials are stored, keyed per server URL and email.
+const keyringService = "rediacc-cli"
+
+// keyringStore persists credentials in the OS-native secret store
+// (Keychain on macOS, Secret Service on Linux, Credential Manager on
+// Windows), falling back to fileStore when no such backend is available,
+// e.g. headless Linux without a Secret Service provider.
+type keyringStore struct {
+	fallback *fileStore
+}
+
+func newKeyringStore() *keyringStore {
+	return &keyringStore{fallback: newFileStore()}
+}
+
+func (*keyringStore) Name() string { return BackendKeyring }
+
+func (k *keyringStore) Get(serverURL, email string) (*Credential, error) {
+	key := credentialKey(serverURL, email)
+
+	if payload, err := keyring.Get(keyringService, key); err == nil {
+		var cred Credential
+		if err := json.Unmarshal([]byte(payload), &cred); err != nil {
+			return nil, fmt.Errorf("failed to parse stored credential: %w", err)
+		}
+		return &cred, nil
+	}
+
+	return k.fallback.Get(serverURL, email)
+}
+
+func (k *keyringStore) Set(serverURL, email string, cred Credential) error {
+	payload, err := json.Marshal(cred)
+	if err != nil {
+		return fmt.Errorf("failed to marshal credential: %w", err)
+	}
+
+	key := credentialKey(serverURL, email)
+	if err := keyring.Set(keyringService, key, string(payload)); err == nil {
+		return nil
+	}
+
+	return k.fallback.Set(serverURL, email, cred)
+}
+
+func (k *keyringStore) Delete(serverURL, email string) error {
+	key := credentialKey(serverURL, email)
+	_ = keyring.Delete(keyringService, key)
+	return k.fallback.Delete(serverURL, email)
+}
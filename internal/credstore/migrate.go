@@ -0,0 +1,24 @@
+package credstore
+
+// MigrateLegacy moves a session/request credential that predates the
+// credential store (written straight into the plaintext config file by
+// CLI versions before this package existed) into store, if store doesn't
+// already hold one for this server+email. It reports whether it wrote
+// anything, so the caller (config.UpdateAuth's callers, in practice) knows
+// to zero-fill the plaintext fields it migrated out of.
+func MigrateLegacy(store Store, serverURL, email, legacySessionToken, legacyRequestCredential string) (bool, error) {
+	if legacyRequestCredential == "" {
+		return false, nil
+	}
+	if _, err := store.Get(serverURL, email); err == nil {
+		return false, nil
+	}
+
+	if err := store.Set(serverURL, email, Credential{
+		SessionToken:      legacySessionToken,
+		RequestCredential: legacyRequestCredential,
+	}); err != nil {
+		return false, err
+	}
+	return true, nil
+}
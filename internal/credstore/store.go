@@ -0,0 +1,54 @@
+// Package credstore holds the session credential (the RequestCredential
+// rotated by `auth login`/`auth refresh`) outside the plaintext config
+// file, behind a pluggable Store so the backend can be swapped per
+// environment: an encrypted OS keyring on a developer workstation, a
+// mode-0600 file when no keyring is available, or an env/stdin-backed
+// store for CI where nothing should touch disk at all.
+package credstore
+
+import "fmt"
+
+// Credential is the session material persisted for one server+email pair.
+type Credential struct {
+	SessionToken      string
+	RequestCredential string
+}
+
+// Store persists and retrieves Credentials for a server+email pair. Get
+// returns an error when no credential is on file; Delete is a no-op (not
+// an error) when there is nothing to remove.
+type Store interface {
+	// Name identifies the backend, e.g. for `auth status` to report which
+	// one is active.
+	Name() string
+	Get(serverURL, email string) (*Credential, error)
+	Set(serverURL, email string, cred Credential) error
+	Delete(serverURL, email string) error
+}
+
+// Backend names accepted by the `credentials.backend` config key and the
+// `--credentials-backend` flag.
+const (
+	BackendKeyring = "keyring"
+	BackendFile    = "file"
+	BackendEnv     = "env"
+)
+
+// Select returns the Store for the named backend. An empty or unrecognized
+// name falls back to BackendKeyring, the historical default behavior.
+func Select(backend string) Store {
+	switch backend {
+	case BackendFile:
+		return newFileStore()
+	case BackendEnv:
+		return newEnvStore()
+	default:
+		return newKeyringStore()
+	}
+}
+
+// errNotFound is returned by a backend's Get when it holds nothing for the
+// given server+email.
+func errNotFound(email string) error {
+	return fmt.Errorf("no stored credential for %s", email)
+}
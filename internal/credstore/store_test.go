@@ -0,0 +1,23 @@
+package credstore
+
+import "testing"
+
+func TestSelect(t *testing.T) {
+	cases := []struct {
+		backend string
+		want    string
+	}{
+		{BackendFile, BackendFile},
+		{BackendEnv, BackendEnv},
+		{BackendKeyring, BackendKeyring},
+		{"", BackendKeyring},
+		{"unknown", BackendKeyring},
+	}
+	for _, tc := range cases {
+		t.Run(tc.backend, func(t *testing.T) {
+			if got := Select(tc.backend).Name(); got != tc.want {
+				t.Fatalf("Select(%q).Name() = %q, want %q", tc.backend, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package crypto
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// VaultCachePath returns ~/.rediacc-cli/vault.age, the encrypted local copy
+// of the company vault maintained by `company vault edit`.
+func VaultCachePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("could not get home directory: %w", err)
+	}
+	return filepath.Join(home, ".rediacc-cli", "vault.age"), nil
+}
+
+// WriteVaultCache encrypts data and writes it to VaultCachePath, creating
+// the parent directory if needed.
+func WriteVaultCache(data []byte, passphrase, identityPath string) error {
+	path, err := VaultCachePath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create vault cache directory: %w", err)
+	}
+
+	ciphertext, err := EncryptVault(data, passphrase, identityPath)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, ciphertext, 0600)
+}
+
+// ReadVaultCache reads and decrypts the cached vault blob, so it can be
+// inspected or diffed without network access.
+func ReadVaultCache(passphrase, identityPath string) ([]byte, error) {
+	path, err := VaultCachePath()
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vault cache %s: %w", path, err)
+	}
+	return DecryptVault(ciphertext, passphrase, identityPath)
+}
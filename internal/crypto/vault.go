@@ -0,0 +1,113 @@
+// Package crypto provides age-based encryption for the local vault cache
+// used by `rediacc company vault edit`. Data is encrypted either to an age
+// identity file (public-key mode) or to a passphrase (scrypt-derived
+// symmetric mode), so the cached blob can be inspected offline without
+// trusting the server's transport alone.
+package crypto
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+
+	"filippo.io/age"
+)
+
+// EncryptVault encrypts plaintext for later offline decryption. When
+// identityPath is non-empty, it is parsed as an age identity file and the
+// corresponding public keys become the recipients; otherwise passphrase is
+// used to derive a symmetric (scrypt) recipient.
+func EncryptVault(plaintext []byte, passphrase, identityPath string) ([]byte, error) {
+	recipients, err := recipientsFor(passphrase, identityPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w, err := age.Encrypt(&buf, recipients...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vault encryption stream: %w", err)
+	}
+	if _, err := w.Write(plaintext); err != nil {
+		return nil, fmt.Errorf("failed to encrypt vault data: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finalize vault encryption: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// DecryptVault reverses EncryptVault. identityPath must match whatever was
+// used to encrypt the blob; passphrase is ignored when identityPath is set.
+func DecryptVault(ciphertext []byte, passphrase, identityPath string) ([]byte, error) {
+	identities, err := identitiesFor(passphrase, identityPath)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := age.Decrypt(bytes.NewReader(ciphertext), identities...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt vault data: %w", err)
+	}
+	plaintext, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read decrypted vault data: %w", err)
+	}
+	return plaintext, nil
+}
+
+// recipientsFor builds the age.Recipient set EncryptVault encrypts to.
+func recipientsFor(passphrase, identityPath string) ([]age.Recipient, error) {
+	if identityPath == "" {
+		recipient, err := age.NewScryptRecipient(passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive vault key from passphrase: %w", err)
+		}
+		return []age.Recipient{recipient}, nil
+	}
+
+	identities, err := parseIdentityFile(identityPath)
+	if err != nil {
+		return nil, err
+	}
+
+	recipients := make([]age.Recipient, 0, len(identities))
+	for _, id := range identities {
+		x25519, ok := id.(*age.X25519Identity)
+		if !ok {
+			continue
+		}
+		recipients = append(recipients, x25519.Recipient())
+	}
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("identity file %s contains no usable X25519 identities", identityPath)
+	}
+	return recipients, nil
+}
+
+// identitiesFor builds the age.Identity set DecryptVault decrypts with.
+func identitiesFor(passphrase, identityPath string) ([]age.Identity, error) {
+	if identityPath == "" {
+		identity, err := age.NewScryptIdentity(passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to derive vault key from passphrase: %w", err)
+		}
+		return []age.Identity{identity}, nil
+	}
+	return parseIdentityFile(identityPath)
+}
+
+func parseIdentityFile(path string) ([]age.Identity, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open vault identity file %s: %w", path, err)
+	}
+	defer f.Close()
+
+	identities, err := age.ParseIdentities(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse vault identity file %s: %w", path, err)
+	}
+	return identities, nil
+}
@@ -0,0 +1,133 @@
+package format
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+)
+
+// DelimitedFormatter handles CSV/TSV-style output, selected via the
+// "csv" and "tsv" --output values. Nested maps are flattened to dotted keys
+// (e.g. "limits.max_machines") since a CSV cell can't hold a nested object.
+// Column order follows columns when set (--columns); otherwise it falls
+// back to the flattened first row's keys, sorted for determinism.
+type DelimitedFormatter struct {
+	separator rune
+	noHeaders bool
+	columns   []string
+}
+
+// NewDelimitedFormatter creates a formatter that writes rows separated by
+// sep, deriving columns from the data itself.
+func NewDelimitedFormatter(sep rune, noHeaders bool) *DelimitedFormatter {
+	return NewDelimitedFormatterWithColumns(sep, noHeaders, nil)
+}
+
+// NewDelimitedFormatterWithColumns creates a formatter that writes rows
+// separated by sep, restricted to and ordered by columns when non-empty.
+func NewDelimitedFormatterWithColumns(sep rune, noHeaders bool, columns []string) *DelimitedFormatter {
+	return &DelimitedFormatter{separator: sep, noHeaders: noHeaders, columns: columns}
+}
+
+// Format writes data as delimited rows to stdout.
+func (f *DelimitedFormatter) Format(data interface{}) error {
+	rows, err := toMapSlice(data)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		fmt.Println("No data to display")
+		return nil
+	}
+
+	flattened := make([]map[string]interface{}, len(rows))
+	for i, row := range rows {
+		flat := make(map[string]interface{})
+		flattenInto(flat, "", row)
+		flattened[i] = flat
+	}
+
+	headers := f.columns
+	if len(headers) == 0 {
+		headers = sortedKeys(flattened[0])
+	}
+
+	w := csv.NewWriter(os.Stdout)
+	w.Comma = f.separator
+
+	if !f.noHeaders {
+		if err := w.Write(headers); err != nil {
+			return fmt.Errorf("failed to write header row: %w", err)
+		}
+	}
+
+	for _, row := range flattened {
+		record := make([]string, len(headers))
+		for i, header := range headers {
+			record[i] = fmt.Sprintf("%v", row[header])
+		}
+		if err := w.Write(record); err != nil {
+			return fmt.Errorf("failed to write row: %w", err)
+		}
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// flattenInto copies row into out, joining nested map keys with "." under
+// prefix. Non-map leaf values, including slices, are kept as-is and
+// stringified by Format via fmt.Sprintf("%v", ...).
+func flattenInto(out map[string]interface{}, prefix string, row map[string]interface{}) {
+	for key, value := range row {
+		fullKey := key
+		if prefix != "" {
+			fullKey = prefix + "." + key
+		}
+		if nested, ok := value.(map[string]interface{}); ok {
+			flattenInto(out, fullKey, nested)
+			continue
+		}
+		out[fullKey] = value
+	}
+}
+
+func sortedKeys(row map[string]interface{}) []string {
+	keys := make([]string, 0, len(row))
+	for key := range row {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// toMapSlice normalizes the shapes Print() commonly receives
+// ([]map[string]interface{}, map[string]interface{}, []interface{} of maps)
+// into a single []map[string]interface{}, since delimited/columnar formats
+// only make sense for tabular data.
+func toMapSlice(data interface{}) ([]map[string]interface{}, error) {
+	switch v := data.(type) {
+	case []map[string]interface{}:
+		return v, nil
+	case map[string]interface{}:
+		return []map[string]interface{}{v}, nil
+	case []interface{}:
+		rows := make([]map[string]interface{}, 0, len(v))
+		for _, item := range v {
+			m, ok := item.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("cannot format non-tabular data as csv/tsv")
+			}
+			rows = append(rows, m)
+		}
+		return rows, nil
+	default:
+		rv := reflect.ValueOf(data)
+		if rv.Kind() == reflect.Slice && rv.Len() == 0 {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("cannot format non-tabular data as csv/tsv")
+	}
+}
@@ -1,10 +1,17 @@
 package format
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/fatih/color"
+	"gopkg.in/yaml.v3"
+
 	"github.com/rediacc/cli/internal/config"
+	"github.com/rediacc/cli/internal/utils"
 )
 
 // Formatter interface for different output formats
@@ -12,54 +19,211 @@ type Formatter interface {
 	Format(data interface{}) error
 }
 
-// GetFormatter returns a formatter based on the specified format
+// factory builds a Formatter for the current configuration (colors,
+// no-headers) and, for formats that carry an inline argument such as
+// `template=...` or `jsonpath=...`, that argument.
+type factory func(arg string, useColors, noHeaders bool) Formatter
+
+// registry maps a format name (the part before "=" in --output) to the
+// factory that builds it. Third-party builds can add formats without
+// touching this file via RegisterFormatter.
+var registry = map[string]factory{}
+
+func init() {
+	RegisterFormatter("table", func(_ string, useColors, noHeaders bool) Formatter {
+		return NewTableFormatterWithColumns(useColors, noHeaders, config.Columns())
+	})
+	RegisterFormatter("json", func(_ string, _, _ bool) Formatter {
+		return NewJSONFormatter(true)
+	})
+	RegisterFormatter("json-compact", func(_ string, _, _ bool) Formatter {
+		return NewJSONFormatter(false)
+	})
+	RegisterFormatter("yaml", func(_ string, _, _ bool) Formatter {
+		return NewYAMLFormatter()
+	})
+	RegisterFormatter("text", func(_ string, _, _ bool) Formatter {
+		return NewTextFormatter()
+	})
+	RegisterFormatter("csv", func(_ string, _, noHeaders bool) Formatter {
+		return NewDelimitedFormatterWithColumns(',', noHeaders, config.Columns())
+	})
+	RegisterFormatter("tsv", func(_ string, _, noHeaders bool) Formatter {
+		return NewDelimitedFormatterWithColumns('\t', noHeaders, config.Columns())
+	})
+	RegisterFormatter("markdown", func(_ string, _, noHeaders bool) Formatter {
+		return NewMarkdownFormatter(noHeaders, config.Columns())
+	})
+	RegisterFormatter("html", func(_ string, _, noHeaders bool) Formatter {
+		return NewHTMLFormatter(noHeaders, config.Columns())
+	})
+	RegisterFormatter("template", func(arg string, _, _ bool) Formatter {
+		return NewTemplateFormatter(arg)
+	})
+	RegisterFormatter("jsonpath", func(arg string, _, _ bool) Formatter {
+		return NewJSONPathFormatter(arg)
+	})
+}
+
+// RegisterFormatter adds or replaces the factory for a format name.
+func RegisterFormatter(name string, f factory) {
+	registry[name] = f
+}
+
+// GetFormatter returns a formatter based on the specified format, which may
+// carry an inline argument as `name=argument` (e.g. `template={{.name}}`).
 func GetFormatter(format string) (Formatter, error) {
 	cfg := config.Get()
 	useColors := cfg.Format.Colors
+	noHeaders := config.NoHeaders()
 
-	switch format {
-	case "table":
-		return NewTableFormatter(useColors), nil
-	case "json":
-		return NewJSONFormatter(true), nil
-	case "json-compact":
-		return NewJSONFormatter(false), nil
-	case "yaml":
-		return NewYAMLFormatter(), nil
-	case "text":
-		return NewTextFormatter(), nil
-	default:
+	name, arg, _ := strings.Cut(format, "=")
+	build, ok := registry[name]
+	if !ok {
 		return nil, fmt.Errorf("unsupported format: %s", format)
 	}
+	return build(arg, useColors, noHeaders), nil
 }
 
-// Print formats and prints data using the configured output format
+// Print formats and prints data using the configured output format, first
+// applying any --query/--jq expression and then any --fields projection.
 func Print(data interface{}) error {
+	if q := config.Query(); q != "" {
+		queried, err := EvaluateQuery(data, q)
+		if err != nil {
+			return err
+		}
+		data = queried
+	}
+
 	format := config.GetOutputFormat()
 	formatter, err := GetFormatter(format)
 	if err != nil {
 		return err
 	}
-	return formatter.Format(data)
+	return formatter.Format(applyFieldProjection(data, config.Fields()))
+}
+
+// PrintWithColumns behaves like Print, but uses cols as the column list for
+// table/csv/tsv/markdown/html output regardless of the global --columns
+// flag, for callers (e.g. `permissions apply --dry-run`) that need a fixed
+// column set for one call.
+func PrintWithColumns(data interface{}, cols []string) error {
+	previous := config.Columns()
+	config.SetColumns(cols)
+	defer config.SetColumns(previous)
+	return Print(data)
+}
+
+// applyFieldProjection narrows []map[string]interface{} and
+// map[string]interface{} values down to the requested, ordered fields.
+// Other data shapes are returned unchanged, since a projection only makes
+// sense for tabular/keyed data.
+func applyFieldProjection(data interface{}, fields []string) interface{} {
+	if len(fields) == 0 {
+		return data
+	}
+
+	switch v := data.(type) {
+	case []map[string]interface{}:
+		projected := make([]map[string]interface{}, len(v))
+		for i, row := range v {
+			projected[i] = projectRow(row, fields)
+		}
+		return projected
+	case map[string]interface{}:
+		return projectRow(v, fields)
+	default:
+		return data
+	}
 }
 
-// PrintSuccess prints a success message
+func projectRow(row map[string]interface{}, fields []string) map[string]interface{} {
+	projected := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		projected[field] = row[field]
+	}
+	return projected
+}
+
+// PrintSuccess prints a success message to the process's stdout. Prefer
+// PrintSuccessTo within a cobra RunE, passing cmd.OutOrStdout(), so output
+// respects any stream a caller (or a future test) wired onto the command.
 func PrintSuccess(message string, args ...interface{}) {
+	PrintSuccessTo(os.Stdout, message, args...)
+}
+
+// PrintSuccessTo writes a success message to w, typically cmd.OutOrStdout().
+func PrintSuccessTo(w io.Writer, message string, args ...interface{}) {
 	cfg := config.Get()
 	if cfg.Format.Colors {
-		color.Green(message, args...)
+		color.New(color.FgGreen).Fprintf(w, message+"\n", args...)
 	} else {
-		fmt.Printf(message+"\n", args...)
+		fmt.Fprintf(w, message+"\n", args...)
 	}
 }
 
-// PrintError prints an error message
+// PrintError prints an error message to stderr, so it stays visible even
+// when a command's normal output is redirected or piped.
 func PrintError(message string, args ...interface{}) {
 	cfg := config.Get()
 	if cfg.Format.Colors {
-		color.Red(message, args...)
+		color.New(color.FgRed).Fprintf(os.Stderr, message+"\n", args...)
 	} else {
-		fmt.Printf("Error: "+message+"\n", args...)
+		fmt.Fprintf(os.Stderr, "Error: "+message+"\n", args...)
+	}
+}
+
+// PrintErrorObject prints err as a structured {"error": {...}} object when
+// the active --output is json, json-compact, or yaml, so scripted callers
+// can key off err's Code/Field instead of string-matching its message; for
+// any other output format it falls back to PrintError.
+func PrintErrorObject(err error) {
+	if err == nil {
+		return
+	}
+
+	name, _, _ := strings.Cut(config.GetOutputFormat(), "=")
+	payload := map[string]interface{}{"error": errorPayload(err)}
+
+	var output []byte
+	var marshalErr error
+	switch name {
+	case "json":
+		output, marshalErr = json.MarshalIndent(payload, "", "  ")
+	case "json-compact":
+		output, marshalErr = json.Marshal(payload)
+	case "yaml":
+		output, marshalErr = yaml.Marshal(payload)
+	default:
+		PrintError("%s", err)
+		return
+	}
+
+	if marshalErr != nil {
+		PrintError("%s", err)
+		return
+	}
+	fmt.Fprintln(os.Stderr, string(output))
+}
+
+// errorPayload shapes err for PrintErrorObject: typed errors keep their
+// structured fields (Code, Field, Details, ...), everything else becomes a
+// plain {"message": "..."}.
+func errorPayload(err error) interface{} {
+	switch e := err.(type) {
+	case *utils.APIError:
+		return e
+	case *utils.ValidationError:
+		return e
+	case *utils.MultiError:
+		messages := make([]string, len(e.Errors))
+		for i, sub := range e.Errors {
+			messages[i] = sub.Error()
+		}
+		return map[string]interface{}{"message": e.Error(), "errors": messages}
+	default:
+		return map[string]string{"message": err.Error()}
 	}
 }
 
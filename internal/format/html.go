@@ -0,0 +1,68 @@
+package format
+
+import (
+	"fmt"
+	"html"
+	"os"
+)
+
+// HTMLFormatter renders tabular data as a plain <table>, selected via
+// `--output html`. Like DelimitedFormatter, nested maps are flattened to
+// dotted keys and column order follows --columns when set, otherwise the
+// flattened first row's keys, sorted for determinism.
+type HTMLFormatter struct {
+	noHeaders bool
+	columns   []string
+}
+
+// NewHTMLFormatter creates an HTML table formatter.
+func NewHTMLFormatter(noHeaders bool, columns []string) *HTMLFormatter {
+	return &HTMLFormatter{noHeaders: noHeaders, columns: columns}
+}
+
+// Format writes data as an HTML table to stdout.
+func (f *HTMLFormatter) Format(data interface{}) error {
+	rows, err := toMapSlice(data)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		fmt.Println("<p>No data to display</p>")
+		return nil
+	}
+
+	flattened := make([]map[string]interface{}, len(rows))
+	for i, row := range rows {
+		flat := make(map[string]interface{})
+		flattenInto(flat, "", row)
+		flattened[i] = flat
+	}
+
+	headers := f.columns
+	if len(headers) == 0 {
+		headers = sortedKeys(flattened[0])
+	}
+
+	w := os.Stdout
+	fmt.Fprintln(w, "<table>")
+	if !f.noHeaders {
+		fmt.Fprintln(w, "  <thead>")
+		fmt.Fprintln(w, "    <tr>")
+		for _, header := range headers {
+			fmt.Fprintf(w, "      <th>%s</th>\n", html.EscapeString(header))
+		}
+		fmt.Fprintln(w, "    </tr>")
+		fmt.Fprintln(w, "  </thead>")
+	}
+	fmt.Fprintln(w, "  <tbody>")
+	for _, row := range flattened {
+		fmt.Fprintln(w, "    <tr>")
+		for _, header := range headers {
+			fmt.Fprintf(w, "      <td>%s</td>\n", html.EscapeString(fmt.Sprintf("%v", row[header])))
+		}
+		fmt.Fprintln(w, "    </tr>")
+	}
+	fmt.Fprintln(w, "  </tbody>")
+	fmt.Fprintln(w, "</table>")
+	return nil
+}
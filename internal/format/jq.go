@@ -0,0 +1,63 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/itchyny/gojq"
+)
+
+// evaluateJQ runs expr as a full jq program (pipes, `select()`, `.[]`
+// iteration, object construction, ...) against data via gojq. It reports
+// ok=false (with a nil error) when expr doesn't parse as jq at all. It is
+// EvaluateQuery's fallback for when its lighter dotted-path expressions
+// can't resolve expr against data — e.g. `select(.active)` or `.[] | .id`.
+//
+// A query that emits exactly one value is unwrapped to that value, so
+// `.users[0].email` behaves like a dotted-path lookup; one that emits zero
+// or several (e.g. `.[] | select(.active)`) comes back as a []interface{}.
+func evaluateJQ(data interface{}, expr string) (value interface{}, ok bool, err error) {
+	query, parseErr := gojq.Parse(expr)
+	if parseErr != nil {
+		return nil, false, nil
+	}
+
+	normalized, err := normalizeForJQ(data)
+	if err != nil {
+		return nil, true, err
+	}
+
+	iter := query.Run(normalized)
+	var results []interface{}
+	for {
+		v, more := iter.Next()
+		if !more {
+			break
+		}
+		if runErr, isErr := v.(error); isErr {
+			return nil, true, fmt.Errorf("jq query %q: %w", expr, runErr)
+		}
+		results = append(results, v)
+	}
+
+	if len(results) == 1 {
+		return results[0], true, nil
+	}
+	return results, true, nil
+}
+
+// normalizeForJQ round-trips data through encoding/json so gojq sees plain
+// map[string]interface{}/[]interface{} values instead of the concrete
+// []map[string]interface{} the rest of this package deals in; gojq's `.[]`
+// iteration requires the latter.
+func normalizeForJQ(data interface{}) (interface{}, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode data for jq: %w", err)
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(raw, &normalized); err != nil {
+		return nil, fmt.Errorf("failed to decode data for jq: %w", err)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,113 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// JSONPathFormatter extracts a value via a small dotted-path expression,
+// e.g. `--output jsonpath=.name` or `--output jsonpath=0.email` against a
+// list. It intentionally supports a minimal subset (dotted map keys and
+// numeric slice indices) rather than the full JSONPath grammar, matching
+// the level of expression the rest of this package's formatters need.
+type JSONPathFormatter struct {
+	path string
+}
+
+// NewJSONPathFormatter creates a formatter for the given dotted path expression.
+func NewJSONPathFormatter(path string) *JSONPathFormatter {
+	return &JSONPathFormatter{path: path}
+}
+
+// Format resolves the path against data and prints the result as JSON.
+func (f *JSONPathFormatter) Format(data interface{}) error {
+	if f.path == "" {
+		return fmt.Errorf("jsonpath output requires --output jsonpath=<path>")
+	}
+
+	value, err := EvaluateQuery(data, f.path)
+	if err != nil {
+		return err
+	}
+
+	output, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal jsonpath result: %w", err)
+	}
+	fmt.Println(string(output))
+	return nil
+}
+
+// EvaluateQuery resolves path against data. It backs both
+// `--output jsonpath=<path>` and the `--query`/`--jq` flag, which applies a
+// path to any data before handing it to any formatter.
+//
+// path is tried as a minimal dotted-path expression first (dotted map keys,
+// numeric slice indices, no leading `.` required): this is the older,
+// simpler syntax scripts may already depend on, and it is unambiguous for
+// the paths it handles, e.g. `users.0.email`. Almost any such path also
+// happens to parse as a (semantically different) jq program — a bare
+// `length` is jq's length builtin, not a field access — so trying jq first
+// would shadow the dotted-path meaning for every field name that collides
+// with a jq builtin. Only when the dotted path can't be resolved against
+// data does path fall back to a full jq program (via evaluateJQ), so
+// pipes, `select()`, `.[]` iteration, and the rest of the jq language still
+// work the same way they would piped through external `jq`.
+func EvaluateQuery(data interface{}, path string) (interface{}, error) {
+	if value, err := evaluateDottedPath(data, path); err == nil {
+		return value, nil
+	} else if value, ok, jqErr := evaluateJQ(data, path); ok {
+		return value, jqErr
+	} else {
+		return nil, err
+	}
+}
+
+func evaluateDottedPath(data interface{}, path string) (interface{}, error) {
+	value := data
+	trimmed := strings.TrimPrefix(path, "$.")
+	trimmed = strings.TrimPrefix(trimmed, ".")
+
+	for _, segment := range strings.Split(trimmed, ".") {
+		if segment == "" {
+			continue
+		}
+		next, err := resolveSegment(value, segment)
+		if err != nil {
+			return nil, fmt.Errorf("query %q: %w", path, err)
+		}
+		value = next
+	}
+	return value, nil
+}
+
+func resolveSegment(value interface{}, segment string) (interface{}, error) {
+	if index, err := strconv.Atoi(segment); err == nil {
+		switch v := value.(type) {
+		case []map[string]interface{}:
+			if index < 0 || index >= len(v) {
+				return nil, fmt.Errorf("index %d out of range", index)
+			}
+			return v[index], nil
+		case []interface{}:
+			if index < 0 || index >= len(v) {
+				return nil, fmt.Errorf("index %d out of range", index)
+			}
+			return v[index], nil
+		default:
+			return nil, fmt.Errorf("cannot index non-list value with [%d]", index)
+		}
+	}
+
+	m, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("cannot access field %q on non-object value", segment)
+	}
+	result, exists := m[segment]
+	if !exists {
+		return nil, fmt.Errorf("field %q not found", segment)
+	}
+	return result, nil
+}
@@ -0,0 +1,73 @@
+package format
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEvaluateQueryDottedPath(t *testing.T) {
+	data := map[string]interface{}{
+		"users": []interface{}{
+			map[string]interface{}{"email": "a@example.com"},
+			map[string]interface{}{"email": "b@example.com"},
+		},
+	}
+
+	got, err := EvaluateQuery(data, "users.1.email")
+	if err != nil {
+		t.Fatalf("EvaluateQuery: %v", err)
+	}
+	if got != "b@example.com" {
+		t.Fatalf("got %v, want b@example.com", got)
+	}
+}
+
+func TestEvaluateQueryDottedPathLeadingDot(t *testing.T) {
+	data := map[string]interface{}{"name": "readers"}
+
+	got, err := EvaluateQuery(data, ".name")
+	if err != nil {
+		t.Fatalf("EvaluateQuery: %v", err)
+	}
+	if got != "readers" {
+		t.Fatalf("got %v, want readers", got)
+	}
+}
+
+func TestEvaluateQueryBareIdentifierFallsBackToDottedPath(t *testing.T) {
+	data := map[string]interface{}{"length": 42}
+
+	got, err := EvaluateQuery(data, "length")
+	if err != nil {
+		t.Fatalf("EvaluateQuery: %v", err)
+	}
+	if got != 42 {
+		t.Fatalf("got %v, want 42 (field access, not jq's length builtin)", got)
+	}
+}
+
+func TestEvaluateQueryFallsBackToJQWhenDottedPathFails(t *testing.T) {
+	data := []interface{}{1, 2, 3}
+
+	got, err := EvaluateQuery(data, "length")
+	if err != nil {
+		t.Fatalf("EvaluateQuery: %v", err)
+	}
+	if fmt.Sprint(got) != "3" {
+		t.Fatalf("got %v (%T), want 3 via jq's length builtin since data isn't an object", got, got)
+	}
+}
+
+func TestEvaluateQueryUnknownFieldErrors(t *testing.T) {
+	data := map[string]interface{}{"name": "readers"}
+	if _, err := EvaluateQuery(data, "missing"); err == nil {
+		t.Fatalf("EvaluateQuery(missing) = nil error, want error")
+	}
+}
+
+func TestEvaluateDottedPathIndexOutOfRange(t *testing.T) {
+	data := []interface{}{"a", "b"}
+	if _, err := evaluateDottedPath(data, "5"); err == nil {
+		t.Fatalf("evaluateDottedPath(5) = nil error, want out-of-range error")
+	}
+}
@@ -0,0 +1,79 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// MarkdownFormatter renders tabular data as a GitHub-flavored Markdown
+// table, selected via `--output markdown`. Like DelimitedFormatter, nested
+// maps are flattened to dotted keys and column order follows --columns when
+// set, otherwise the flattened first row's keys, sorted for determinism.
+type MarkdownFormatter struct {
+	noHeaders bool
+	columns   []string
+}
+
+// NewMarkdownFormatter creates a Markdown table formatter.
+func NewMarkdownFormatter(noHeaders bool, columns []string) *MarkdownFormatter {
+	return &MarkdownFormatter{noHeaders: noHeaders, columns: columns}
+}
+
+// Format writes data as a Markdown table to stdout.
+func (f *MarkdownFormatter) Format(data interface{}) error {
+	rows, err := toMapSlice(data)
+	if err != nil {
+		return err
+	}
+	if len(rows) == 0 {
+		fmt.Println("No data to display")
+		return nil
+	}
+
+	flattened := make([]map[string]interface{}, len(rows))
+	for i, row := range rows {
+		flat := make(map[string]interface{})
+		flattenInto(flat, "", row)
+		flattened[i] = flat
+	}
+
+	headers := f.columns
+	if len(headers) == 0 {
+		headers = sortedKeys(flattened[0])
+	}
+
+	return writeMarkdownTable(os.Stdout, headers, flattened, f.noHeaders)
+}
+
+func writeMarkdownTable(w io.Writer, headers []string, rows []map[string]interface{}, noHeaders bool) error {
+	if !noHeaders {
+		fmt.Fprintf(w, "| %s |\n", strings.Join(headers, " | "))
+		fmt.Fprintf(w, "| %s |\n", strings.Join(repeatString("---", len(headers)), " | "))
+	}
+	for _, row := range rows {
+		cells := make([]string, len(headers))
+		for i, header := range headers {
+			cells[i] = escapeMarkdownCell(fmt.Sprintf("%v", row[header]))
+		}
+		fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | "))
+	}
+	return nil
+}
+
+func repeatString(s string, n int) []string {
+	out := make([]string, n)
+	for i := range out {
+		out[i] = s
+	}
+	return out
+}
+
+// escapeMarkdownCell escapes the characters that would otherwise break a
+// Markdown table cell: pipes (column separators) and newlines.
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	s = strings.ReplaceAll(s, "\n", "<br>")
+	return s
+}
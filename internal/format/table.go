@@ -11,9 +11,14 @@ import (
 	"github.com/olekukonko/tablewriter"
 )
 
-// TableFormatter handles table output formatting
+// TableFormatter handles table output formatting. Column order for
+// []map[string]interface{} data follows columns when set (--columns);
+// otherwise it falls back to the first row's keys, sorted for determinism
+// (see sortedKeys) rather than Go's nondeterministic map iteration order.
 type TableFormatter struct {
 	useColors bool
+	noHeaders bool
+	columns   []string
 }
 
 // NewTableFormatter creates a new table formatter
@@ -23,6 +28,22 @@ func NewTableFormatter(useColors bool) *TableFormatter {
 	}
 }
 
+// NewTableFormatterWithOptions creates a table formatter with header
+// visibility control, for scripting via --no-headers.
+func NewTableFormatterWithOptions(useColors, noHeaders bool) *TableFormatter {
+	return NewTableFormatterWithColumns(useColors, noHeaders, nil)
+}
+
+// NewTableFormatterWithColumns creates a table formatter restricted to and
+// ordered by columns when non-empty.
+func NewTableFormatterWithColumns(useColors, noHeaders bool, columns []string) *TableFormatter {
+	return &TableFormatter{
+		useColors: useColors,
+		noHeaders: noHeaders,
+		columns:   columns,
+	}
+}
+
 // Format formats data as a table
 func (f *TableFormatter) Format(data interface{}) error {
 	if data == nil {
@@ -43,37 +64,38 @@ func (f *TableFormatter) Format(data interface{}) error {
 	}
 }
 
-// formatMapSlice formats a slice of maps as a table
+// formatMapSlice formats a slice of maps as a table. Headers are derived
+// from f.columns (or the first row's keys, sorted) and paired with their
+// original key throughout, so there's no lossy header<->key round-trip for
+// formatMapSlice to undo (see the now-removed headerToKey).
 func (f *TableFormatter) formatMapSlice(data []map[string]interface{}) error {
 	if len(data) == 0 {
 		fmt.Println("No data to display")
 		return nil
 	}
 
-	// Get headers from first row
-	headers := make([]string, 0)
-	for key := range data[0] {
-		headers = append(headers, f.formatHeader(key))
+	keys := f.columns
+	if len(keys) == 0 {
+		keys = sortedKeys(data[0])
+	}
+
+	headers := make([]string, len(keys))
+	for i, key := range keys {
+		headers[i] = f.formatHeader(key)
 	}
 
 	// Create table
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader(headers)
+	f.setHeader(table, headers)
 
 	// Configure table appearance
 	f.configureTable(table)
 
 	// Add rows
 	for _, row := range data {
-		values := make([]string, len(headers))
-		for i, header := range headers {
-			// Convert header back to original key
-			key := f.headerToKey(header)
-			if val, exists := row[key]; exists {
-				values[i] = f.formatValue(val)
-			} else {
-				values[i] = ""
-			}
+		values := make([]string, len(keys))
+		for i, key := range keys {
+			values[i] = f.formatValue(row[key])
 		}
 		table.Append(values)
 	}
@@ -85,7 +107,7 @@ func (f *TableFormatter) formatMapSlice(data []map[string]interface{}) error {
 // formatSingleMap formats a single map as a vertical table
 func (f *TableFormatter) formatSingleMap(data map[string]interface{}) error {
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Property", "Value"})
+	f.setHeader(table, []string{"Property", "Value"})
 
 	f.configureTable(table)
 
@@ -124,7 +146,7 @@ func (f *TableFormatter) formatInterfaceSlice(data []interface{}) error {
 // formatSimpleList formats a simple list of values
 func (f *TableFormatter) formatSimpleList(data []interface{}) error {
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Value"})
+	f.setHeader(table, []string{"Value"})
 
 	f.configureTable(table)
 
@@ -160,7 +182,7 @@ func (f *TableFormatter) formatReflection(data interface{}) error {
 // formatStruct formats a struct as a vertical table
 func (f *TableFormatter) formatStruct(v reflect.Value, t reflect.Type) error {
 	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Field", "Value"})
+	f.setHeader(table, []string{"Field", "Value"})
 
 	f.configureTable(table)
 
@@ -196,6 +218,13 @@ func (f *TableFormatter) formatSlice(v reflect.Value) error {
 	return f.formatInterfaceSlice(data)
 }
 
+// setHeader sets the table header unless --no-headers was requested.
+func (f *TableFormatter) setHeader(table *tablewriter.Table, headers []string) {
+	if !f.noHeaders {
+		table.SetHeader(headers)
+	}
+}
+
 // configureTable sets up table appearance
 func (f *TableFormatter) configureTable(table *tablewriter.Table) {
 	table.SetAutoWrapText(false)
@@ -229,16 +258,6 @@ func (f *TableFormatter) formatHeader(header string) string {
 	return strings.Join(words, " ")
 }
 
-// headerToKey converts a formatted header back to original key
-func (f *TableFormatter) headerToKey(header string) string {
-	// Convert Title Case back to snake_case
-	words := strings.Split(header, " ")
-	for i, word := range words {
-		words[i] = strings.ToLower(word)
-	}
-	return strings.Join(words, "_")
-}
-
 // formatValue formats a value for display
 func (f *TableFormatter) formatValue(value interface{}) string {
 	if value == nil {
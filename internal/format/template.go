@@ -0,0 +1,50 @@
+package format
+
+import (
+	"fmt"
+	"os"
+	"text/template"
+)
+
+// TemplateFormatter renders data through a user-supplied Go text/template
+// string, e.g. `--output template={{.email}} {{.status}}`. A trailing
+// newline is appended automatically so templates don't need one.
+type TemplateFormatter struct {
+	text string
+}
+
+// NewTemplateFormatter creates a template formatter for the given template text.
+func NewTemplateFormatter(text string) *TemplateFormatter {
+	return &TemplateFormatter{text: text}
+}
+
+// Format executes the template once per row for tabular data, or once for
+// a single object.
+func (f *TemplateFormatter) Format(data interface{}) error {
+	if f.text == "" {
+		return fmt.Errorf("template output requires --output template=<go-template>")
+	}
+
+	tmpl, err := template.New("output").Parse(f.text)
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+
+	rows, err := toMapSlice(data)
+	if err != nil {
+		// Not tabular data (e.g. a plain string); execute once against the raw value.
+		if err := tmpl.Execute(os.Stdout, data); err != nil {
+			return fmt.Errorf("failed to execute template: %w", err)
+		}
+		fmt.Println()
+		return nil
+	}
+
+	for _, row := range rows {
+		if err := tmpl.Execute(os.Stdout, row); err != nil {
+			return fmt.Errorf("failed to execute template: %w", err)
+		}
+		fmt.Println()
+	}
+	return nil
+}
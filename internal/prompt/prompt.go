@@ -0,0 +1,137 @@
+// Package prompt provides terminal helpers for reading sensitive input
+// (passwords, tokens) without echoing it to the screen or leaving it in
+// shell history.
+package prompt
+
+import (
+	"bufio"
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// defaultPasswordAlphabet is used by GeneratePassword; it avoids characters
+// that are easy to confuse (0/O, 1/l/I) while still covering upper, lower,
+// digit, and symbol classes.
+const defaultPasswordAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnpqrstuvwxyz23456789!@#$%^&*-_="
+
+// Password reads a single line from the terminal with echo disabled,
+// falling back to a plain, visible read when stdin is not a TTY (e.g. in
+// tests or when piped).
+func Password(label string) (string, error) {
+	fmt.Fprint(os.Stderr, label)
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return readLine(os.Stdin)
+	}
+
+	data, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", fmt.Errorf("failed to read password: %w", err)
+	}
+	return string(data), nil
+}
+
+// PasswordWithConfirmation prompts twice and requires the two entries to
+// match, retrying on mismatch.
+func PasswordWithConfirmation(label string) (string, error) {
+	for {
+		first, err := Password(label)
+		if err != nil {
+			return "", err
+		}
+		second, err := Password("Confirm " + label)
+		if err != nil {
+			return "", err
+		}
+		if first != second {
+			fmt.Fprintln(os.Stderr, "Passwords do not match, please try again.")
+			continue
+		}
+		return first, nil
+	}
+}
+
+// FromFile reads a password from the first line of path, trimming the
+// trailing newline. Used for --password-file flags.
+func FromFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to open password file: %w", err)
+	}
+	defer f.Close()
+
+	password, err := readLine(f)
+	if err != nil {
+		return "", fmt.Errorf("failed to read password file: %w", err)
+	}
+	return password, nil
+}
+
+// FromStdin reads a password from the first line of stdin. Used for
+// --password-stdin flags.
+func FromStdin() (string, error) {
+	return readLine(os.Stdin)
+}
+
+// readLine reads a single line from r and trims the trailing newline (and
+// carriage return, for CRLF input).
+func readLine(r *os.File) (string, error) {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return "", err
+		}
+		return "", fmt.Errorf("no input provided")
+	}
+	return strings.TrimRight(scanner.Text(), "\r\n"), nil
+}
+
+// GeneratePassword returns a cryptographically random password of length
+// characters drawn from defaultPasswordAlphabet.
+func GeneratePassword(length int) (string, error) {
+	if length <= 0 {
+		length = 20
+	}
+
+	alphabetSize := big.NewInt(int64(len(defaultPasswordAlphabet)))
+	result := make([]byte, length)
+	for i := range result {
+		n, err := rand.Int(rand.Reader, alphabetSize)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate password: %w", err)
+		}
+		result[i] = defaultPasswordAlphabet[n.Int64()]
+	}
+	return string(result), nil
+}
+
+// Resolve picks the password source in priority order: an explicit value
+// (e.g. already supplied via flag), then --password-file, then
+// --password-stdin, then --generate-password, falling back to an
+// interactive TTY prompt with confirmation.
+func Resolve(explicit, passwordFile string, passwordStdin, generate bool, label string) (string, error) {
+	switch {
+	case explicit != "":
+		return explicit, nil
+	case passwordFile != "":
+		return FromFile(passwordFile)
+	case passwordStdin:
+		return FromStdin()
+	case generate:
+		generated, err := GeneratePassword(20)
+		if err != nil {
+			return "", err
+		}
+		fmt.Println(generated)
+		return generated, nil
+	default:
+		return PasswordWithConfirmation(label)
+	}
+}
@@ -5,15 +5,22 @@ import (
 	"net/http"
 )
 
-// APIError represents an API error
+// APIError represents an error returned by the middleware API, decoded from
+// its error payload rather than built from a generic fmt.Errorf string so
+// scripted callers can key off Code instead of matching Message text.
 type APIError struct {
-	StatusCode int    `json:"status_code"`
-	Message    string `json:"message"`
-	Code       string `json:"code"`
+	StatusCode int                    `json:"status_code"`
+	Message    string                 `json:"message"`
+	Code       string                 `json:"code,omitempty"`
+	Field      string                 `json:"field,omitempty"`
+	Details    map[string]interface{} `json:"details,omitempty"`
 }
 
 // Error implements the error interface
 func (e *APIError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("API error (%d) [%s]: %s", e.StatusCode, e.Code, e.Message)
+	}
 	return fmt.Sprintf("API error (%d): %s", e.StatusCode, e.Message)
 }
 
@@ -26,6 +33,22 @@ func NewAPIError(statusCode int, message, code string) *APIError {
 	}
 }
 
+// WithField records which request field the error applies to, for
+// validation-style API errors (e.g. "email" on a 409 COMPANY_EXISTS).
+func (e *APIError) WithField(field string) *APIError {
+	e.Field = field
+	return e
+}
+
+// WithDetails attaches any extra structured data the server returned
+// alongside the error (e.g. the middleware's Outputs map).
+func (e *APIError) WithDetails(details map[string]interface{}) *APIError {
+	if len(details) > 0 {
+		e.Details = details
+	}
+	return e
+}
+
 // IsAuthError checks if the error is an authentication error
 func IsAuthError(err error) bool {
 	if apiErr, ok := err.(*APIError); ok {
@@ -3,10 +3,16 @@ package utils
 import (
 	"fmt"
 	"net/mail"
-	"regexp"
 	"strings"
+
+	"github.com/rediacc/cli/internal/validate"
 )
 
+// The ValidateX functions below are thin, back-compat wrappers over
+// internal/validate's composable Rule framework; new code should prefer
+// validate.Validate with struct tags, or validate's Rule primitives
+// directly, over adding more functions here.
+
 // ValidateEmail validates an email address
 func ValidateEmail(email string) error {
 	if email == "" {
@@ -47,17 +53,12 @@ func ValidateName(name, fieldName string) error {
 	if err := ValidateRequired(name, fieldName); err != nil {
 		return err
 	}
-
-	if len(name) > 255 {
+	if err := validate.MaxLen(255).Check(name); err != nil {
 		return fmt.Errorf("%s must be less than 255 characters", fieldName)
 	}
-
-	// Check for valid characters (letters, numbers, spaces, hyphens, underscores)
-	validName := regexp.MustCompile(`^[a-zA-Z0-9\s\-_]+$`)
-	if !validName.MatchString(name) {
+	if err := validate.Regexp(`^[a-zA-Z0-9\s\-_]+$`).Check(name); err != nil {
 		return fmt.Errorf("%s contains invalid characters", fieldName)
 	}
-
 	return nil
 }
 
@@ -66,59 +67,48 @@ func ValidateAlias(alias, fieldName string) error {
 	if err := ValidateRequired(alias, fieldName); err != nil {
 		return err
 	}
-
-	if len(alias) > 100 {
+	if err := validate.MaxLen(100).Check(alias); err != nil {
 		return fmt.Errorf("%s must be less than 100 characters", fieldName)
 	}
-
-	// Check for valid characters (letters, numbers, hyphens, underscores only)
-	validAlias := regexp.MustCompile(`^[a-zA-Z0-9\-_]+$`)
-	if !validAlias.MatchString(alias) {
+	if err := validate.Regexp(`^[a-zA-Z0-9\-_]+$`).Check(alias); err != nil {
 		return fmt.Errorf("%s can only contain letters, numbers, hyphens, and underscores", fieldName)
 	}
-
 	return nil
 }
 
-// ValidateIP validates an IP address
+// ValidateIP validates an IP address. It accepts both IPv4 and IPv6 (via
+// net/netip), unlike the IPv4-only regex this used to be.
 func ValidateIP(ip string) error {
 	if err := ValidateRequired(ip, "IP address"); err != nil {
 		return err
 	}
-
-	// Simple IPv4 validation
-	ipv4Pattern := regexp.MustCompile(`^(?:(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)\.){3}(?:25[0-5]|2[0-4][0-9]|[01]?[0-9][0-9]?)$`)
-	if !ipv4Pattern.MatchString(ip) {
+	if err := validate.IPAddress().Check(ip); err != nil {
 		return fmt.Errorf("invalid IP address format")
 	}
-
 	return nil
 }
 
-// ValidateURL validates a URL
+// ValidateURL validates a URL. It accepts "localhost" and explicit ports
+// (via net/url), unlike the regex this used to be, which rejected both.
 func ValidateURL(url string) error {
 	if err := ValidateRequired(url, "URL"); err != nil {
 		return err
 	}
-
-	urlPattern := regexp.MustCompile(`^https?://[a-zA-Z0-9\-\.]+\.[a-zA-Z]{2,}(?:/.*)?$`)
-	if !urlPattern.MatchString(url) {
+	if err := validate.URL("http", "https").Check(url); err != nil {
 		return fmt.Errorf("invalid URL format")
 	}
-
 	return nil
 }
 
-// ValidateSize validates a size string (e.g., "100G", "1T")
+// ValidateSize validates a size string (e.g., "100G", "1.5GiB", "512MB").
+// It accepts both SI and IEC suffixes and decimal values, unlike the
+// integer-only, no-"i"-suffix regex this used to be.
 func ValidateSize(size string) error {
 	if err := ValidateRequired(size, "size"); err != nil {
 		return err
 	}
-
-	sizePattern := regexp.MustCompile(`^[0-9]+[KMGTPE]?[B]?$`)
-	if !sizePattern.MatchString(strings.ToUpper(size)) {
-		return fmt.Errorf("invalid size format (use format like 100G, 1T)")
+	if _, err := validate.ParseByteSize(size); err != nil {
+		return fmt.Errorf("invalid size format (use format like 100G, 1.5GiB, 512MB)")
 	}
-
 	return nil
 }
@@ -0,0 +1,61 @@
+// Package validate is a small, composable validation framework: a Rule
+// interface, combinators to build rules out of other rules, a set of
+// common primitives, and a Validate function that reads `validate:"..."`
+// struct tags so a cobra flag struct can declare its constraints once
+// instead of hand-rolling checks at the top of each RunE.
+package validate
+
+// Rule validates a single value, returning a descriptive error (with no
+// field name attached; that's added by whoever calls Check, e.g. Validate)
+// when the value fails the rule.
+type Rule interface {
+	Check(value interface{}) error
+}
+
+// RuleFunc adapts a plain function to the Rule interface.
+type RuleFunc func(value interface{}) error
+
+// Check implements Rule.
+func (f RuleFunc) Check(value interface{}) error {
+	return f(value)
+}
+
+// All passes only if every rule passes, returning the first failure.
+func All(rules ...Rule) Rule {
+	return RuleFunc(func(value interface{}) error {
+		for _, rule := range rules {
+			if err := rule.Check(value); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// Any passes if at least one rule passes. If every rule fails, it returns
+// the first rule's error, since that's usually the most relevant one for a
+// set of rules tried in priority order (e.g. "IPv4 or hostname").
+func Any(rules ...Rule) Rule {
+	return RuleFunc(func(value interface{}) error {
+		var firstErr error
+		for _, rule := range rules {
+			if err := rule.Check(value); err == nil {
+				return nil
+			} else if firstErr == nil {
+				firstErr = err
+			}
+		}
+		return firstErr
+	})
+}
+
+// When only applies rule when cond(value) is true, e.g. making a field
+// required only in combination with another field's value.
+func When(cond func(value interface{}) bool, rule Rule) Rule {
+	return RuleFunc(func(value interface{}) error {
+		if !cond(value) {
+			return nil
+		}
+		return rule.Check(value)
+	})
+}
@@ -0,0 +1,252 @@
+package validate
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Required fails when value is the zero value for its type: "", 0, nil,
+// an empty slice/map, an unset time.Time, and so on.
+func Required() Rule {
+	return RuleFunc(func(value interface{}) error {
+		if value == nil {
+			return fmt.Errorf("is required")
+		}
+		rv := reflect.ValueOf(value)
+		if (rv.Kind() == reflect.String || rv.Kind() == reflect.Slice || rv.Kind() == reflect.Map) && rv.Len() == 0 {
+			return fmt.Errorf("is required")
+		}
+		if rv.IsZero() {
+			return fmt.Errorf("is required")
+		}
+		return nil
+	})
+}
+
+// MaxLen fails when value's string form is longer than n runes.
+func MaxLen(n int) Rule {
+	return RuleFunc(func(value interface{}) error {
+		s, err := asString(value)
+		if err != nil {
+			return err
+		}
+		if utf8.RuneCountInString(s) > n {
+			return fmt.Errorf("must be at most %d characters", n)
+		}
+		return nil
+	})
+}
+
+// Regexp fails when value's string form doesn't match pattern. pattern is
+// expected to be a compile-time constant; a bad pattern panics, the same
+// way regexp.MustCompile does.
+func Regexp(pattern string) Rule {
+	re := regexp.MustCompile(pattern)
+	return RuleFunc(func(value interface{}) error {
+		s, err := asString(value)
+		if err != nil {
+			return err
+		}
+		if !re.MatchString(s) {
+			return fmt.Errorf("does not match pattern %s", pattern)
+		}
+		return nil
+	})
+}
+
+// OneOf fails unless value's string form exactly matches one of options.
+func OneOf(options ...string) Rule {
+	return RuleFunc(func(value interface{}) error {
+		s, err := asString(value)
+		if err != nil {
+			return err
+		}
+		for _, option := range options {
+			if s == option {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of %s", strings.Join(options, ", "))
+	})
+}
+
+// CIDR fails unless value's string form is a valid CIDR block, e.g.
+// "10.0.0.0/8" or "2001:db8::/32".
+func CIDR() Rule {
+	return RuleFunc(func(value interface{}) error {
+		s, err := asString(value)
+		if err != nil {
+			return err
+		}
+		if _, _, err := net.ParseCIDR(s); err != nil {
+			return fmt.Errorf("invalid CIDR block: %w", err)
+		}
+		return nil
+	})
+}
+
+// hostnamePattern matches an RFC 1123 hostname: dot-separated labels of
+// alphanumerics and hyphens, neither leading nor trailing with a hyphen.
+var hostnamePattern = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?(\.[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?)*$`)
+
+// Hostname fails unless value's string form is a syntactically valid
+// RFC 1123 hostname (this does not resolve it).
+func Hostname() Rule {
+	return RuleFunc(func(value interface{}) error {
+		s, err := asString(value)
+		if err != nil {
+			return err
+		}
+		if !hostnamePattern.MatchString(s) {
+			return fmt.Errorf("invalid hostname")
+		}
+		return nil
+	})
+}
+
+// Duration fails unless value's string form parses via time.ParseDuration,
+// e.g. "30s", "5m", "1h30m".
+func Duration() Rule {
+	return RuleFunc(func(value interface{}) error {
+		s, err := asString(value)
+		if err != nil {
+			return err
+		}
+		if _, err := time.ParseDuration(s); err != nil {
+			return fmt.Errorf("invalid duration: %w", err)
+		}
+		return nil
+	})
+}
+
+// ByteSize fails unless value's string form parses via ParseByteSize.
+func ByteSize() Rule {
+	return RuleFunc(func(value interface{}) error {
+		s, err := asString(value)
+		if err != nil {
+			return err
+		}
+		_, err = ParseByteSize(s)
+		return err
+	})
+}
+
+// IPAddress fails unless value's string form is a valid IPv4 or IPv6
+// address (via net/netip, so it accepts both families, unlike a
+// hand-rolled IPv4-only regex).
+func IPAddress() Rule {
+	return RuleFunc(func(value interface{}) error {
+		s, err := asString(value)
+		if err != nil {
+			return err
+		}
+		if _, err := netip.ParseAddr(s); err != nil {
+			return fmt.Errorf("invalid IP address: %w", err)
+		}
+		return nil
+	})
+}
+
+// URL fails unless value's string form parses as an absolute URL whose
+// scheme is one of schemes (case-insensitive) and that carries a host.
+// Unlike a regex, this accepts "localhost" and explicit ports.
+func URL(schemes ...string) Rule {
+	return RuleFunc(func(value interface{}) error {
+		s, err := asString(value)
+		if err != nil {
+			return err
+		}
+		parsed, err := url.Parse(s)
+		if err != nil {
+			return fmt.Errorf("invalid URL: %w", err)
+		}
+		if parsed.Host == "" {
+			return fmt.Errorf("invalid URL: missing host")
+		}
+		for _, scheme := range schemes {
+			if strings.EqualFold(parsed.Scheme, scheme) {
+				return nil
+			}
+		}
+		return fmt.Errorf("URL scheme must be one of %s", strings.Join(schemes, ", "))
+	})
+}
+
+// asString coerces value to a string for the rules above, which are all
+// fundamentally textual checks. Rules are meant to run against string
+// struct fields and flag values; anything else is a caller error.
+func asString(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case fmt.Stringer:
+		return v.String(), nil
+	default:
+		return "", fmt.Errorf("validation rule requires a string value, got %T", value)
+	}
+}
+
+// byteSizePattern matches a decimal value followed by an optional SI
+// (K, M, G, T, P, E) or IEC (Ki, Mi, Gi, Ti, Pi, Ei) suffix and an
+// optional trailing "B", e.g. "100G", "1.5GiB", "512MB", "2048".
+var byteSizePattern = regexp.MustCompile(`(?i)^([0-9]+(?:\.[0-9]+)?)\s*([kmgtpe]i?)?b?$`)
+
+var siByteMultiples = map[string]uint64{
+	"":  1,
+	"k": 1_000,
+	"m": 1_000_000,
+	"g": 1_000_000_000,
+	"t": 1_000_000_000_000,
+	"p": 1_000_000_000_000_000,
+	"e": 1_000_000_000_000_000_000,
+}
+
+var iecByteMultiples = map[string]uint64{
+	"ki": 1 << 10,
+	"mi": 1 << 20,
+	"gi": 1 << 30,
+	"ti": 1 << 40,
+	"pi": 1 << 50,
+	"ei": 1 << 60,
+}
+
+// ParseByteSize parses a human-readable size like "100G", "1.5GiB", or
+// "512MB" into a byte count. It accepts both SI (powers of 1000) and IEC
+// (powers of 1024) suffixes, with or without a trailing "B", and decimal
+// values ("1.5GiB"), unlike the integer-only, no-"i"-suffix regex this
+// replaces.
+func ParseByteSize(s string) (uint64, error) {
+	trimmed := strings.TrimSpace(s)
+	if trimmed == "" {
+		return 0, fmt.Errorf("size is required")
+	}
+
+	match := byteSizePattern.FindStringSubmatch(trimmed)
+	if match == nil {
+		return 0, fmt.Errorf("invalid size %q (expected e.g. 100G, 1.5GiB, 512MB)", s)
+	}
+
+	amount, err := strconv.ParseFloat(match[1], 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid size %q: %w", s, err)
+	}
+
+	suffix := strings.ToLower(match[2])
+	multiple, ok := iecByteMultiples[suffix]
+	if !ok {
+		multiple, ok = siByteMultiples[suffix]
+	}
+	if !ok {
+		return 0, fmt.Errorf("invalid size %q: unrecognized suffix %q", s, match[2])
+	}
+
+	return uint64(amount * float64(multiple)), nil
+}
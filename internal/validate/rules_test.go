@@ -0,0 +1,175 @@
+package validate
+
+import "testing"
+
+func TestRequired(t *testing.T) {
+	cases := []struct {
+		name    string
+		value   interface{}
+		wantErr bool
+	}{
+		{"empty string", "", true},
+		{"non-empty string", "x", false},
+		{"nil", nil, true},
+		{"empty slice", []string{}, true},
+		{"non-empty slice", []string{"x"}, false},
+		{"zero int", 0, true},
+		{"non-zero int", 1, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Required().Check(tc.value)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Required().Check(%#v) error = %v, wantErr %v", tc.value, err, tc.wantErr)
+			}
+		})
+	}
+}
+
+func TestMaxLen(t *testing.T) {
+	rule := MaxLen(3)
+	if err := rule.Check("abc"); err != nil {
+		t.Fatalf("Check(\"abc\") = %v, want nil", err)
+	}
+	if err := rule.Check("abcd"); err == nil {
+		t.Fatalf("Check(\"abcd\") = nil, want error")
+	}
+}
+
+func TestRegexp(t *testing.T) {
+	rule := Regexp(`^[a-z]+$`)
+	if err := rule.Check("abc"); err != nil {
+		t.Fatalf("Check(\"abc\") = %v, want nil", err)
+	}
+	if err := rule.Check("ABC"); err == nil {
+		t.Fatalf("Check(\"ABC\") = nil, want error")
+	}
+}
+
+func TestOneOf(t *testing.T) {
+	rule := OneOf("a", "b", "c")
+	if err := rule.Check("b"); err != nil {
+		t.Fatalf("Check(\"b\") = %v, want nil", err)
+	}
+	if err := rule.Check("d"); err == nil {
+		t.Fatalf("Check(\"d\") = nil, want error")
+	}
+}
+
+func TestCIDR(t *testing.T) {
+	rule := CIDR()
+	if err := rule.Check("10.0.0.0/8"); err != nil {
+		t.Fatalf("Check(\"10.0.0.0/8\") = %v, want nil", err)
+	}
+	if err := rule.Check("not-a-cidr"); err == nil {
+		t.Fatalf("Check(\"not-a-cidr\") = nil, want error")
+	}
+}
+
+func TestHostname(t *testing.T) {
+	rule := Hostname()
+	if err := rule.Check("my-host.example.com"); err != nil {
+		t.Fatalf("Check(\"my-host.example.com\") = %v, want nil", err)
+	}
+	if err := rule.Check("-bad-host"); err == nil {
+		t.Fatalf("Check(\"-bad-host\") = nil, want error")
+	}
+}
+
+func TestDuration(t *testing.T) {
+	rule := Duration()
+	if err := rule.Check("1h30m"); err != nil {
+		t.Fatalf("Check(\"1h30m\") = %v, want nil", err)
+	}
+	if err := rule.Check("not-a-duration"); err == nil {
+		t.Fatalf("Check(\"not-a-duration\") = nil, want error")
+	}
+}
+
+func TestIPAddress(t *testing.T) {
+	rule := IPAddress()
+	if err := rule.Check("192.168.1.1"); err != nil {
+		t.Fatalf("Check(\"192.168.1.1\") = %v, want nil", err)
+	}
+	if err := rule.Check("::1"); err != nil {
+		t.Fatalf("Check(\"::1\") = %v, want nil", err)
+	}
+	if err := rule.Check("not-an-ip"); err == nil {
+		t.Fatalf("Check(\"not-an-ip\") = nil, want error")
+	}
+}
+
+func TestURL(t *testing.T) {
+	rule := URL("http", "https")
+	if err := rule.Check("https://example.com"); err != nil {
+		t.Fatalf("Check(\"https://example.com\") = %v, want nil", err)
+	}
+	if err := rule.Check("ftp://example.com"); err == nil {
+		t.Fatalf("Check(\"ftp://example.com\") = nil, want error for disallowed scheme")
+	}
+	if err := rule.Check("https:///no-host"); err == nil {
+		t.Fatalf("Check(\"https:///no-host\") = nil, want error for missing host")
+	}
+}
+
+func TestAll(t *testing.T) {
+	rule := All(Required(), MaxLen(3))
+	if err := rule.Check("ab"); err != nil {
+		t.Fatalf("Check(\"ab\") = %v, want nil", err)
+	}
+	if err := rule.Check(""); err == nil {
+		t.Fatalf("Check(\"\") = nil, want the Required failure")
+	}
+	if err := rule.Check("abcd"); err == nil {
+		t.Fatalf("Check(\"abcd\") = nil, want the MaxLen failure")
+	}
+}
+
+func TestAny(t *testing.T) {
+	rule := Any(IPAddress(), Hostname())
+	if err := rule.Check("192.168.1.1"); err != nil {
+		t.Fatalf("Check(\"192.168.1.1\") = %v, want nil", err)
+	}
+	if err := rule.Check("example.com"); err != nil {
+		t.Fatalf("Check(\"example.com\") = %v, want nil", err)
+	}
+	if err := rule.Check("!!!"); err == nil {
+		t.Fatalf("Check(\"!!!\") = nil, want error when neither rule passes")
+	}
+}
+
+func TestWhen(t *testing.T) {
+	rule := When(func(value interface{}) bool { return value == "trigger" }, Required())
+	if err := rule.Check("anything else"); err != nil {
+		t.Fatalf("Check with cond false = %v, want nil (rule skipped)", err)
+	}
+	if err := rule.Check("trigger"); err != nil {
+		t.Fatalf("Check with cond true = %v, want nil since value is non-empty", err)
+	}
+}
+
+func TestParseByteSize(t *testing.T) {
+	cases := []struct {
+		input   string
+		want    uint64
+		wantErr bool
+	}{
+		{"100", 100, false},
+		{"100G", 100_000_000_000, false},
+		{"1.5GiB", uint64(1.5 * (1 << 30)), false},
+		{"512MB", 512_000_000, false},
+		{"", 0, true},
+		{"not-a-size", 0, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := ParseByteSize(tc.input)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseByteSize(%q) error = %v, wantErr %v", tc.input, err, tc.wantErr)
+			}
+			if err == nil && got != tc.want {
+				t.Fatalf("ParseByteSize(%q) = %d, want %d", tc.input, got, tc.want)
+			}
+		})
+	}
+}
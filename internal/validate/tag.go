@@ -0,0 +1,152 @@
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// FieldError is one field's validation failure, as collected by Validate.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s %s", e.Field, e.Message)
+}
+
+// FieldErrors collects every FieldError Validate found, rather than
+// stopping at the first, so a cobra command can report every invalid flag
+// in one pass instead of one failure per invocation.
+type FieldErrors []*FieldError
+
+// Error implements the error interface.
+func (e FieldErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+	messages := make([]string, len(e))
+	for i, fe := range e {
+		messages[i] = fe.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// tagRuleBuilders maps a `validate` tag segment's name to a constructor
+// taking that segment's "=value" argument (empty for argument-less rules
+// like "required"). Third-party builds can add tag names without touching
+// this file via RegisterTagRule.
+var tagRuleBuilders = map[string]func(arg string) (Rule, error){
+	"required": func(string) (Rule, error) { return Required(), nil },
+	"max": func(arg string) (Rule, error) {
+		n, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("max=%s: %w", arg, err)
+		}
+		return MaxLen(n), nil
+	},
+	"oneof": func(arg string) (Rule, error) {
+		options := strings.Fields(arg)
+		if len(options) == 0 {
+			return nil, fmt.Errorf("oneof requires at least one option")
+		}
+		return OneOf(options...), nil
+	},
+	"regexp":   func(arg string) (Rule, error) { return Regexp(arg), nil },
+	"cidr":     func(string) (Rule, error) { return CIDR(), nil },
+	"hostname": func(string) (Rule, error) { return Hostname(), nil },
+	"duration": func(string) (Rule, error) { return Duration(), nil },
+	"bytesize": func(string) (Rule, error) { return ByteSize(), nil },
+	"ip":       func(string) (Rule, error) { return IPAddress(), nil },
+	"url": func(arg string) (Rule, error) {
+		schemes := strings.Fields(arg)
+		if len(schemes) == 0 {
+			schemes = []string{"http", "https"}
+		}
+		return URL(schemes...), nil
+	},
+}
+
+// RegisterTagRule adds or replaces the constructor for a `validate` tag
+// name, e.g. RegisterTagRule("port", func(string) (Rule, error) { ... }).
+func RegisterTagRule(name string, build func(arg string) (Rule, error)) {
+	tagRuleBuilders[name] = build
+}
+
+// Validate walks target (a struct or pointer to a non-nil struct) and
+// checks each field's `validate:"..."` tag against the field's value,
+// e.g. `validate:"required,max=255"`. It collects every failing field into
+// a FieldErrors rather than stopping at the first, so callers like a
+// cobra RunE can report every invalid flag in one message.
+func Validate(target any) error {
+	rv := reflect.ValueOf(target)
+	for rv.Kind() == reflect.Ptr {
+		if rv.IsNil() {
+			return fmt.Errorf("validate: nil %s", rv.Type())
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("validate: %s is not a struct", rv.Type())
+	}
+
+	var errs FieldErrors
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		tag, ok := field.Tag.Lookup("validate")
+		if !ok || tag == "-" {
+			continue
+		}
+
+		rule, err := parseTag(tag)
+		if err != nil {
+			errs = append(errs, &FieldError{Field: fieldName(field), Message: err.Error()})
+			continue
+		}
+		if err := rule.Check(rv.Field(i).Interface()); err != nil {
+			errs = append(errs, &FieldError{Field: fieldName(field), Message: err.Error()})
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// parseTag compiles a `validate:"rule1,rule2=arg,..."` tag into a single
+// Rule that requires every listed rule to pass.
+func parseTag(tag string) (Rule, error) {
+	parts := strings.Split(tag, ",")
+	rules := make([]Rule, 0, len(parts))
+	for _, part := range parts {
+		name, arg, _ := strings.Cut(part, "=")
+		build, ok := tagRuleBuilders[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown validation rule %q", name)
+		}
+		rule, err := build(arg)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return All(rules...), nil
+}
+
+// fieldName prefers a field's `yaml` tag name (matching how this CLI's
+// config/flag structs are usually tagged) over its Go identifier, so
+// error messages read like the flag or config key the user actually set.
+func fieldName(field reflect.StructField) string {
+	if tag, ok := field.Tag.Lookup("yaml"); ok {
+		name, _, _ := strings.Cut(tag, ",")
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return field.Name
+}
@@ -0,0 +1,77 @@
+package validate
+
+import (
+	"fmt"
+	"testing"
+)
+
+type tagTestTarget struct {
+	Email string `yaml:"email" validate:"required,regexp=^[^@\\s]+@[^@\\s]+\\.[^@\\s]+$"`
+	Name  string `yaml:"name" validate:"max=5"`
+}
+
+func TestValidateCollectsAllFieldErrors(t *testing.T) {
+	err := Validate(&tagTestTarget{Email: "", Name: "toolong"})
+	if err == nil {
+		t.Fatalf("Validate() = nil, want errors for both fields")
+	}
+	fieldErrs, ok := err.(FieldErrors)
+	if !ok {
+		t.Fatalf("Validate() error type = %T, want FieldErrors", err)
+	}
+	if len(fieldErrs) != 2 {
+		t.Fatalf("len(FieldErrors) = %d, want 2: %v", len(fieldErrs), fieldErrs)
+	}
+	if fieldErrs[0].Field != "email" || fieldErrs[1].Field != "name" {
+		t.Fatalf("FieldErrors fields = [%s, %s], want [email, name]", fieldErrs[0].Field, fieldErrs[1].Field)
+	}
+}
+
+func TestValidatePasses(t *testing.T) {
+	if err := Validate(&tagTestTarget{Email: "user@example.com", Name: "ok"}); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+}
+
+func TestValidateUnknownTagRule(t *testing.T) {
+	type badTarget struct {
+		Field string `validate:"nosuchrule"`
+	}
+	err := Validate(&badTarget{Field: "x"})
+	if err == nil {
+		t.Fatalf("Validate() = nil, want an error for an unknown tag rule")
+	}
+}
+
+func TestValidateRejectsNonStruct(t *testing.T) {
+	s := "not a struct"
+	if err := Validate(&s); err == nil {
+		t.Fatalf("Validate(&string) = nil, want error")
+	}
+}
+
+func TestRegisterTagRule(t *testing.T) {
+	RegisterTagRule("evenlen", func(string) (Rule, error) {
+		return RuleFunc(func(value interface{}) error {
+			s, err := asString(value)
+			if err != nil {
+				return err
+			}
+			if len(s)%2 != 0 {
+				return fmt.Errorf("must have even length")
+			}
+			return nil
+		}), nil
+	})
+	defer delete(tagRuleBuilders, "evenlen")
+
+	type target struct {
+		Field string `validate:"evenlen"`
+	}
+	if err := Validate(&target{Field: "ab"}); err != nil {
+		t.Fatalf("Validate() = %v, want nil for even-length field", err)
+	}
+	if err := Validate(&target{Field: "abc"}); err == nil {
+		t.Fatalf("Validate() = nil, want error for odd-length field")
+	}
+}
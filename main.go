@@ -4,10 +4,17 @@ import (
 	"os"
 
 	"github.com/rediacc/cli/cmd"
+	"github.com/rediacc/cli/internal/cliutil"
+	"github.com/rediacc/cli/internal/format"
 )
 
 func main() {
+	// Commands with business logic report their own failures via
+	// cliutil.Fatal and exit directly. An error surfacing here means cobra
+	// itself rejected the invocation (unknown command, bad flag, failed
+	// PersistentPreRunE), which is always a usage error.
 	if err := cmd.Execute(); err != nil {
-		os.Exit(1)
+		format.PrintErrorObject(err)
+		os.Exit(cliutil.ExitUsageError)
 	}
 }